@@ -0,0 +1,138 @@
+package mpris
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	propertiesInterface               = "org.freedesktop.DBus.Properties"
+	propertiesGetMethod               = propertiesInterface + ".Get"
+	propertiesSetMethod               = propertiesInterface + ".Set"
+	propertiesGetAllMethod            = propertiesInterface + ".GetAll"
+	propertiesPropertiesChangedMember = "PropertiesChanged"
+)
+
+// PlayerChange contains the properties of the player which have changed. Properties which have not been changed are
+// nil.
+type PlayerChange struct {
+	PlaybackStatus *PlaybackStatus
+	LoopStatus     *LoopStatus
+	Rate           *float64
+	Shuffle        *bool
+	Metadata       *Metadata
+	Volume         *float64
+	MinimumRate    *float64
+	MaximumRate    *float64
+	CanGoNext      *bool
+	CanGoPrevious  *bool
+	CanPlay        *bool
+	CanPause       *bool
+	CanSeek        *bool
+	CanControl     *bool
+}
+
+// Changes emits a PlayerChange whenever properties of the player changed until the given context is done. The
+// returned channel will be closed afterwards.
+// Properties which have been invalidated by the player (changed without value) will be fetched again. Note that the
+// Position property never emits changes, use Seeked instead.
+// see: https://dbus.freedesktop.org/doc/dbus-specification.html#standard-interfaces-properties
+func (p Player) Changes(ctx context.Context) (<-chan PlayerChange, error) {
+	changes := make(chan PlayerChange)
+	err := p.subscribe(ctx, propertiesInterface, propertiesPropertiesChangedMember, func(sig *dbus.Signal) {
+		if len(sig.Body) != 3 { // invalid event
+			return
+		}
+		iface, okIface := sig.Body[0].(string)
+		changed, okChanged := sig.Body[1].(map[string]dbus.Variant)
+		invalidated, okInvalidated := sig.Body[2].([]string)
+		if !okIface || !okChanged || !okInvalidated || // broken signal
+			iface != playerInterface { // irrelevant interface
+			return
+		}
+
+		var change PlayerChange
+		var ok bool
+		for name, v := range changed {
+			ok = change.set(name, v) || ok
+		}
+		for _, name := range invalidated {
+			v, err := p.getProperty(ctx, playerInterface+"."+name)
+			if err != nil { // not available anymore
+				continue
+			}
+			ok = change.set(name, v) || ok
+		}
+		if !ok { // no known property has been changed
+			return
+		}
+
+		select {
+		case changes <- change:
+		case <-ctx.Done():
+		}
+	}, func() {
+		close(changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// set sets the property with the given name (without interface) to the value of v. It returns false when the property
+// is unknown or v does not contain a value of the expected type.
+func (c *PlayerChange) set(name string, v dbus.Variant) bool {
+	switch name {
+	case "PlaybackStatus":
+		return setPtr(&c.PlaybackStatus, name, v, func(property string, v dbus.Variant) (PlaybackStatus, error) {
+			s, err := decodeValue[string](property, v)
+			return PlaybackStatus(s), err
+		})
+	case "LoopStatus":
+		return setPtr(&c.LoopStatus, name, v, func(property string, v dbus.Variant) (LoopStatus, error) {
+			s, err := decodeValue[string](property, v)
+			return LoopStatus(s), err
+		})
+	case "Metadata":
+		return setPtr(&c.Metadata, name, v, func(property string, v dbus.Variant) (Metadata, error) {
+			return decodeValue[map[string]dbus.Variant](property, v)
+		})
+	case "Rate":
+		return setPtr(&c.Rate, name, v, decodeFloat64)
+	case "Shuffle":
+		return setPtr(&c.Shuffle, name, v, decodeValue[bool])
+	case "Volume":
+		return setPtr(&c.Volume, name, v, decodeFloat64)
+	case "MinimumRate":
+		return setPtr(&c.MinimumRate, name, v, decodeFloat64)
+	case "MaximumRate":
+		return setPtr(&c.MaximumRate, name, v, decodeFloat64)
+	case "CanGoNext":
+		return setPtr(&c.CanGoNext, name, v, decodeValue[bool])
+	case "CanGoPrevious":
+		return setPtr(&c.CanGoPrevious, name, v, decodeValue[bool])
+	case "CanPlay":
+		return setPtr(&c.CanPlay, name, v, decodeValue[bool])
+	case "CanPause":
+		return setPtr(&c.CanPause, name, v, decodeValue[bool])
+	case "CanSeek":
+		return setPtr(&c.CanSeek, name, v, decodeValue[bool])
+	case "CanControl":
+		return setPtr(&c.CanControl, name, v, decodeValue[bool])
+	}
+
+	return false
+}
+
+func setPtr[T any](dst **T, name string, v dbus.Variant, decode func(property string, v dbus.Variant) (T, error)) bool {
+	t, err := decode(name, v)
+	if err != nil {
+		return false
+	}
+
+	*dst = &t
+	return true
+}
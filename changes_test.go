@@ -0,0 +1,208 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayer_Changes(t *testing.T) {
+	playing := PlaybackStatusPlaying
+	loopTrack := LoopStatusTrack
+	metadata := Metadata{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1"))}
+	volume := 0.5
+	rate := 1.5
+	canSeek := true
+	maximumRate := 2.0
+
+	tests := []struct {
+		name string
+
+		givenSignals    []*dbus.Signal
+		givenProperties map[string]dbus.Variant
+
+		nameOwnerErr                    error
+		addMatchSignalErr               error
+		expectedAddMatchSignalCallCount int
+		expectedSignalCallCount         int
+
+		expectedErr     string
+		expectedChanges []PlayerChange
+	}{
+		{
+			name:                            "happycase",
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         1,
+			givenProperties: map[string]dbus.Variant{
+				"org.mpris.MediaPlayer2.Player.Volume": dbus.MakeVariant(0.5),
+			},
+			givenSignals: []*dbus.Signal{
+				{
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2.Player",
+						map[string]dbus.Variant{
+							"PlaybackStatus": dbus.MakeVariant("Playing"),
+							"Metadata":       dbus.MakeVariant(map[string]dbus.Variant(metadata)),
+						},
+						[]string{},
+					},
+				}, { // other interface
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2",
+						map[string]dbus.Variant{"Fullscreen": dbus.MakeVariant(true)},
+						[]string{},
+					},
+				}, { // other sender
+					Sender: ":1.8",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2.Player",
+						map[string]dbus.Variant{"Shuffle": dbus.MakeVariant(true)},
+						[]string{},
+					},
+				}, { // missing body infos
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2.Player",
+						map[string]dbus.Variant{"Shuffle": dbus.MakeVariant(true)},
+					},
+				}, { // unknown property and invalid property type only
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2.Player",
+						map[string]dbus.Variant{
+							"Unknown": dbus.MakeVariant(true),
+							"Shuffle": dbus.MakeVariant("nope"),
+						},
+						[]string{"Unavailable"},
+					},
+				}, { // invalidated property
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+					Body: []interface{}{
+						"org.mpris.MediaPlayer2.Player",
+						map[string]dbus.Variant{
+							"LoopStatus": dbus.MakeVariant("Track"),
+							"Rate":       dbus.MakeVariant(1.5),
+							"CanSeek":    dbus.MakeVariant(true),
+							// lenient numeric types
+							"MaximumRate": dbus.MakeVariant(int32(2)),
+						},
+						[]string{"Volume"},
+					},
+				},
+			},
+			expectedChanges: []PlayerChange{
+				{
+					PlaybackStatus: &playing,
+					Metadata:       &metadata,
+				}, {
+					LoopStatus:  &loopTrack,
+					Rate:        &rate,
+					Volume:      &volume,
+					MaximumRate: &maximumRate,
+					CanSeek:     &canSeek,
+				},
+			},
+		}, {
+			name:                            "name owner error",
+			nameOwnerErr:                    errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 0,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to get owner of \"changes\": unexpected error",
+		}, {
+			name:                            "add match signal error",
+			addMatchSignalErr:               errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to add signal match option: unexpected error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			mock := &dbusConnMock{
+				ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+					return &dbusBusObjectMock{
+						CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+							return &dbusCallMock{
+								StoreFunc: func(retvalues ...interface{}) error {
+									*retvalues[0].(*string) = ":1.7"
+									return tt.nameOwnerErr
+								},
+							}
+						},
+						GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+							v, ok := tt.givenProperties[p]
+							if !ok {
+								return dbus.Variant{}, errors.New("unknown property")
+							}
+							return v, nil
+						},
+					}
+				},
+				AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return tt.addMatchSignalErr
+				},
+				RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return nil
+				},
+				SignalFunc: func(ch chan<- *dbus.Signal) {
+					go func() {
+						for _, sig := range tt.givenSignals {
+							ch <- sig
+						}
+					}()
+				},
+				RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+			}
+
+			changes, err := Player{name: "changes", connection: mock}.Changes(testCtx)
+			require.Equal(t, tt.expectedErr, msgOrEmpty(err))
+
+			var collectedChanges []PlayerChange
+			if changes != nil {
+				for len(collectedChanges) < len(tt.expectedChanges) {
+					c, ok := <-changes
+					if !ok {
+						break
+					}
+					collectedChanges = append(collectedChanges, c)
+				}
+				cancel()
+				for range changes { // wait until closed
+				}
+
+				assert.Equal(t, []dbus.MatchOption{
+					dbus.WithMatchSender("changes"),
+					dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
+					dbus.WithMatchInterface("org.freedesktop.DBus.Properties"),
+					dbus.WithMatchMember("PropertiesChanged"),
+				}, mock.AddMatchSignalCalls()[0].MatchOptions)
+			}
+			assert.EqualValues(t, tt.expectedChanges, collectedChanges)
+			assert.Equal(t, tt.expectedAddMatchSignalCallCount, len(mock.AddMatchSignalCalls()))
+			assert.Equal(t, tt.expectedSignalCallCount, len(mock.SignalCalls()))
+		})
+	}
+}
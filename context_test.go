@@ -0,0 +1,129 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayer_ContextTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      func(ctx context.Context, p Player) error
+		expectedErr string
+	}{
+		{
+			name: "method call",
+			action: func(ctx context.Context, p Player) error {
+				return p.NextContext(ctx)
+			},
+			expectedErr: "failed to call method \"org.mpris.MediaPlayer2.Player.Next\": context deadline exceeded",
+		}, {
+			name: "method call with return values",
+			action: func(ctx context.Context, p Player) error {
+				_, err := p.TrackList().GetTracksMetadataContext(ctx, []dbus.ObjectPath{"/track/1"})
+				return err
+			},
+			expectedErr: "failed to call method \"org.mpris.MediaPlayer2.TrackList.GetTracksMetadata\": context deadline exceeded",
+		}, {
+			name: "get property",
+			action: func(ctx context.Context, p Player) error {
+				_, err := p.IdentityContext(ctx)
+				return err
+			},
+			expectedErr: "failed to get property \"org.mpris.MediaPlayer2.Identity\": context deadline exceeded",
+		}, {
+			name: "set property",
+			action: func(ctx context.Context, p Player) error {
+				return p.SetVolumeContext(ctx, 0.5)
+			},
+			expectedErr: "failed to set property \"org.mpris.MediaPlayer2.Player.Volume\": context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			// simulates a hung player which only returns when the call has been canceled
+			mock := &dbusConnMock{
+				ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+					return &dbusBusObjectMock{
+						CallWithContextFunc: func(ctx context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+							return &dbusCallMock{
+								StoreFunc: func(_ ...interface{}) error {
+									<-ctx.Done()
+									return ctx.Err()
+								},
+							}
+						},
+						GetPropertyWithContextFunc: func(ctx context.Context, _ string) (dbus.Variant, error) {
+							<-ctx.Done()
+							return dbus.Variant{}, ctx.Err()
+						},
+						SetPropertyWithContextFunc: func(ctx context.Context, _ string, _ interface{}) error {
+							<-ctx.Done()
+							return ctx.Err()
+						},
+					}
+				},
+			}
+
+			err := tt.action(ctx, Player{name: "context", connection: mock})
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		})
+	}
+}
+
+func TestPlayer_ContextForwarding(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "given")
+
+	var givenCtxs []context.Context
+	p := Player{
+		name: "context",
+		connection: &dbusConnMock{
+			ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+				return &dbusBusObjectMock{
+					CallWithContextFunc: func(ctx context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+						givenCtxs = append(givenCtxs, ctx)
+						return &dbusCallMock{
+							StoreFunc: func(_ ...interface{}) error {
+								return nil
+							},
+						}
+					},
+					GetPropertyWithContextFunc: func(ctx context.Context, _ string) (dbus.Variant, error) {
+						givenCtxs = append(givenCtxs, ctx)
+						return dbus.MakeVariant(true), nil
+					},
+					SetPropertyWithContextFunc: func(ctx context.Context, _ string, _ interface{}) error {
+						givenCtxs = append(givenCtxs, ctx)
+						return nil
+					},
+				}
+			},
+		},
+	}
+
+	assert.NoError(t, p.PlayContext(ctx))
+	_, err := p.CanPlayContext(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SetShuffleContext(ctx, true))
+	assert.NoError(t, p.RaiseContext(ctx))
+	assert.NoError(t, p.TrackList().GoToContext(ctx, "/track/1"))
+	_, err = p.TrackList().CanEditTracksContext(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Playlists().ActivatePlaylistContext(ctx, "/playlist/1"))
+
+	assert.Len(t, givenCtxs, 7)
+	for _, givenCtx := range givenCtxs {
+		assert.Equal(t, "given", givenCtx.Value(ctxKey{}))
+	}
+}
@@ -0,0 +1,114 @@
+package mpris
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DatePrecision represents the precision of a date parsed by ParseDate.
+type DatePrecision int
+
+const (
+	// DatePrecisionYear represents a date with year only (e.g. "2019").
+	DatePrecisionYear DatePrecision = iota + 1
+	// DatePrecisionMonth represents a date with year and month (e.g. "2019-01").
+	DatePrecisionMonth
+	// DatePrecisionDay represents a date without time (e.g. "2019-01-01").
+	DatePrecisionDay
+	// DatePrecisionHour represents a date with time in hours (e.g. "2019-01-01T10Z").
+	DatePrecisionHour
+	// DatePrecisionMinute represents a date with time in minutes (e.g. "2019-01-01T10:00+01:00").
+	DatePrecisionMinute
+	// DatePrecisionSecond represents a date with time in seconds (e.g. "2019-01-01T10:00:00Z").
+	DatePrecisionSecond
+	// DatePrecisionFractionalSecond represents a date with time in fractions of a second (e.g. "2019-01-01T10:00:00.5Z").
+	DatePrecisionFractionalSecond
+)
+
+// ParseDate parses a date in ISO 8601 format as used by the xesam date fields. Supported are dates with year only
+// ("2019"), with month ("2019-01"), with day ("2019-01-01") and with time in hours, minutes, seconds or fractions of a
+// second ("2019-01-01T10:00:00.123"). The time may be separated by a space instead of "T" and may be followed by "Z" or
+// an offset ("+01:00", "+0100" or "+01"). Dates without offset are returned in UTC. Missing components are set to
+// their minimum (e.g. "2019" is 2019-01-01T00:00:00Z).
+// Besides the time, the precision of the given date is returned.
+// see: https://www.freedesktop.org/wiki/Specifications/mpris-spec/metadata/
+func ParseDate(s string) (time.Time, DatePrecision, error) {
+	datePart, timePart, hasTime := strings.Cut(s, "T")
+	if !hasTime {
+		datePart, timePart, hasTime = strings.Cut(s, " ")
+	}
+
+	var layout string
+	var precision DatePrecision
+	switch len(datePart) {
+	case len("2006"):
+		layout, precision = "2006", DatePrecisionYear
+	case len("2006-01"):
+		layout, precision = "2006-01", DatePrecisionMonth
+	case len("2006-01-02"):
+		layout, precision = "2006-01-02", DatePrecisionDay
+	default:
+		return time.Time{}, 0, fmt.Errorf("could not parse date %q: %w", s, ErrTypeNotParsable)
+	}
+
+	value := datePart
+	if hasTime {
+		if precision != DatePrecisionDay {
+			return time.Time{}, 0, fmt.Errorf("could not parse date %q: %w", s, ErrTypeNotParsable)
+		}
+
+		clock, zone := splitZone(timePart)
+		clock = strings.Replace(clock, ",", ".", 1)
+		hms, fraction, hasFraction := strings.Cut(clock, ".")
+		switch {
+		case strings.Count(hms, ":") == 0:
+			layout, precision = layout+"T15", DatePrecisionHour
+		case strings.Count(hms, ":") == 1:
+			layout, precision = layout+"T15:04", DatePrecisionMinute
+		case hasFraction && fraction != "":
+			// fractional seconds are accepted by time.Parse without being part of the layout
+			layout, precision = layout+"T15:04:05", DatePrecisionFractionalSecond
+		default:
+			layout, precision = layout+"T15:04:05", DatePrecisionSecond
+		}
+		if hasFraction && precision != DatePrecisionFractionalSecond {
+			return time.Time{}, 0, fmt.Errorf("could not parse date %q: %w", s, ErrTypeNotParsable)
+		}
+
+		value += "T" + clock
+		if zone != "" {
+			layout += "Z07:00"
+			value += zone
+		}
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("could not parse date %q: %s: %w", s, err, ErrTypeNotParsable)
+	}
+
+	return t, precision, nil
+}
+
+// splitZone splits the given time (e.g. "10:00+0100") into the clock and the zone in the form "Z" or "+01:00".
+func splitZone(s string) (string, string) {
+	if strings.HasSuffix(s, "Z") {
+		return strings.TrimSuffix(s, "Z"), "Z"
+	}
+
+	i := strings.LastIndexAny(s, "+-")
+	if i < 0 {
+		return s, ""
+	}
+
+	clock, offset := s[:i], s[i:]
+	switch len(offset) {
+	case len("+01"):
+		offset += ":00"
+	case len("+0100"):
+		offset = offset[:3] + ":" + offset[3:]
+	}
+
+	return clock, offset
+}
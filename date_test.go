@@ -0,0 +1,110 @@
+package mpris
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		given             string
+		expectedTime      time.Time
+		expectedPrecision DatePrecision
+		expectedErr       string
+	}{
+		{
+			given:             "2019",
+			expectedTime:      time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionYear,
+		}, {
+			given:             "2019-03",
+			expectedTime:      time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionMonth,
+		}, {
+			given:             "2019-03-04",
+			expectedTime:      time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionDay,
+		}, {
+			given:             "2019-03-04T10Z",
+			expectedTime:      time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionHour,
+		}, {
+			given:             "2007-04-29T13:56+00:00",
+			expectedTime:      time.Date(2007, 4, 29, 13, 56, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionMinute,
+		}, {
+			given:             "2019-03-04T10:00:00Z",
+			expectedTime:      time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionSecond,
+		}, {
+			given:             "2019-03-04 10:00:00",
+			expectedTime:      time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionSecond,
+		}, {
+			given:             "2019-03-04T10:00:00+0130",
+			expectedTime:      time.Date(2019, 3, 4, 8, 30, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionSecond,
+		}, {
+			given:             "2019-03-04T10:00:00-02",
+			expectedTime:      time.Date(2019, 3, 4, 12, 0, 0, 0, time.UTC),
+			expectedPrecision: DatePrecisionSecond,
+		}, {
+			given:             "2019-03-04T10:00:00.125Z",
+			expectedTime:      time.Date(2019, 3, 4, 10, 0, 0, 125000000, time.UTC),
+			expectedPrecision: DatePrecisionFractionalSecond,
+		}, {
+			given:             "2019-03-04T10:00:00,5+01:00",
+			expectedTime:      time.Date(2019, 3, 4, 9, 0, 0, 500000000, time.UTC),
+			expectedPrecision: DatePrecisionFractionalSecond,
+		}, {
+			given:       "",
+			expectedErr: "could not parse date \"\": the given type is not as expected",
+		}, {
+			given:       "19",
+			expectedErr: "could not parse date \"19\": the given type is not as expected",
+		}, {
+			given:       "2019-13",
+			expectedErr: "could not parse date \"2019-13\": parsing time \"2019-13\": month out of range: the given type is not as expected",
+		}, {
+			given:       "2019-03T10:00",
+			expectedErr: "could not parse date \"2019-03T10:00\": the given type is not as expected",
+		}, {
+			given:       "2019-03-04T10:00.5",
+			expectedErr: "could not parse date \"2019-03-04T10:00.5\": the given type is not as expected",
+		}, {
+			given:       "2019-03-04T10:00:00+1",
+			expectedErr: "could not parse date \"2019-03-04T10:00:00+1\": parsing time \"2019-03-04T10:00:00+1\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"+1\" as \"Z07:00\": the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			d, precision, err := ParseDate(tt.given)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.True(t, tt.expectedTime.Equal(d), "unexpected time", tt.expectedTime, d)
+			assert.Equal(t, tt.expectedPrecision, precision)
+		})
+	}
+}
+
+func TestMetadata_Date(t *testing.T) {
+	d, precision, err := Metadata{
+		"xesam:lastUsed": dbus.MakeVariant("2019-03-04"),
+	}.Date("xesam:lastUsed")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC), d)
+	assert.Equal(t, DatePrecisionDay, precision)
+
+	d, precision, err = Metadata{}.Date("xesam:lastUsed")
+	assert.NoError(t, err)
+	assert.True(t, d.IsZero())
+	assert.Equal(t, DatePrecision(0), precision)
+
+	_, _, err = Metadata{
+		"xesam:lastUsed": dbus.MakeVariant(int32(2019)),
+	}.Date("xesam:lastUsed")
+	assert.EqualError(t, err, "int32 could not be parsed to string: the given type is not as expected")
+}
@@ -4,6 +4,7 @@
 package mpris
 
 import (
+	"context"
 	"github.com/godbus/dbus/v5"
 	"sync"
 )
@@ -18,14 +19,14 @@ var _ dbusBusObject = &dbusBusObjectMock{}
 //
 //		// make and configure a mocked dbusBusObject
 //		mockeddbusBusObject := &dbusBusObjectMock{
-//			CallFunc: func(method string, flags dbus.Flags, args ...interface{}) dbusCall {
-//				panic("mock out the Call method")
+//			CallWithContextFunc: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+//				panic("mock out the CallWithContext method")
 //			},
-//			GetPropertyFunc: func(p string) (dbus.Variant, error) {
-//				panic("mock out the GetProperty method")
+//			GetPropertyWithContextFunc: func(ctx context.Context, p string) (dbus.Variant, error) {
+//				panic("mock out the GetPropertyWithContext method")
 //			},
-//			SetPropertyFunc: func(p string, v interface{}) error {
-//				panic("mock out the SetProperty method")
+//			SetPropertyWithContextFunc: func(ctx context.Context, p string, v interface{}) error {
+//				panic("mock out the SetPropertyWithContext method")
 //			},
 //		}
 //
@@ -34,19 +35,21 @@ var _ dbusBusObject = &dbusBusObjectMock{}
 //
 //	}
 type dbusBusObjectMock struct {
-	// CallFunc mocks the Call method.
-	CallFunc func(method string, flags dbus.Flags, args ...interface{}) dbusCall
+	// CallWithContextFunc mocks the CallWithContext method.
+	CallWithContextFunc func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall
 
-	// GetPropertyFunc mocks the GetProperty method.
-	GetPropertyFunc func(p string) (dbus.Variant, error)
+	// GetPropertyWithContextFunc mocks the GetPropertyWithContext method.
+	GetPropertyWithContextFunc func(ctx context.Context, p string) (dbus.Variant, error)
 
-	// SetPropertyFunc mocks the SetProperty method.
-	SetPropertyFunc func(p string, v interface{}) error
+	// SetPropertyWithContextFunc mocks the SetPropertyWithContext method.
+	SetPropertyWithContextFunc func(ctx context.Context, p string, v interface{}) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Call holds details about calls to the Call method.
-		Call []struct {
+		// CallWithContext holds details about calls to the CallWithContext method.
+		CallWithContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Method is the method argument value.
 			Method string
 			// Flags is the flags argument value.
@@ -54,128 +57,144 @@ type dbusBusObjectMock struct {
 			// Args is the args argument value.
 			Args []interface{}
 		}
-		// GetProperty holds details about calls to the GetProperty method.
-		GetProperty []struct {
+		// GetPropertyWithContext holds details about calls to the GetPropertyWithContext method.
+		GetPropertyWithContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// P is the p argument value.
 			P string
 		}
-		// SetProperty holds details about calls to the SetProperty method.
-		SetProperty []struct {
+		// SetPropertyWithContext holds details about calls to the SetPropertyWithContext method.
+		SetPropertyWithContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// P is the p argument value.
 			P string
 			// V is the v argument value.
 			V interface{}
 		}
 	}
-	lockCall        sync.RWMutex
-	lockGetProperty sync.RWMutex
-	lockSetProperty sync.RWMutex
+	lockCallWithContext        sync.RWMutex
+	lockGetPropertyWithContext sync.RWMutex
+	lockSetPropertyWithContext sync.RWMutex
 }
 
-// Call calls CallFunc.
-func (mock *dbusBusObjectMock) Call(method string, flags dbus.Flags, args ...interface{}) dbusCall {
-	if mock.CallFunc == nil {
-		panic("dbusBusObjectMock.CallFunc: method is nil but dbusBusObject.Call was just called")
+// CallWithContext calls CallWithContextFunc.
+func (mock *dbusBusObjectMock) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+	if mock.CallWithContextFunc == nil {
+		panic("dbusBusObjectMock.CallWithContextFunc: method is nil but dbusBusObject.CallWithContext was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		Method string
 		Flags  dbus.Flags
 		Args   []interface{}
 	}{
+		Ctx:    ctx,
 		Method: method,
 		Flags:  flags,
 		Args:   args,
 	}
-	mock.lockCall.Lock()
-	mock.calls.Call = append(mock.calls.Call, callInfo)
-	mock.lockCall.Unlock()
-	return mock.CallFunc(method, flags, args...)
+	mock.lockCallWithContext.Lock()
+	mock.calls.CallWithContext = append(mock.calls.CallWithContext, callInfo)
+	mock.lockCallWithContext.Unlock()
+	return mock.CallWithContextFunc(ctx, method, flags, args...)
 }
 
-// CallCalls gets all the calls that were made to Call.
+// CallWithContextCalls gets all the calls that were made to CallWithContext.
 // Check the length with:
 //
-//	len(mockeddbusBusObject.CallCalls())
-func (mock *dbusBusObjectMock) CallCalls() []struct {
+//	len(mockeddbusBusObject.CallWithContextCalls())
+func (mock *dbusBusObjectMock) CallWithContextCalls() []struct {
+	Ctx    context.Context
 	Method string
 	Flags  dbus.Flags
 	Args   []interface{}
 } {
 	var calls []struct {
+		Ctx    context.Context
 		Method string
 		Flags  dbus.Flags
 		Args   []interface{}
 	}
-	mock.lockCall.RLock()
-	calls = mock.calls.Call
-	mock.lockCall.RUnlock()
+	mock.lockCallWithContext.RLock()
+	calls = mock.calls.CallWithContext
+	mock.lockCallWithContext.RUnlock()
 	return calls
 }
 
-// GetProperty calls GetPropertyFunc.
-func (mock *dbusBusObjectMock) GetProperty(p string) (dbus.Variant, error) {
-	if mock.GetPropertyFunc == nil {
-		panic("dbusBusObjectMock.GetPropertyFunc: method is nil but dbusBusObject.GetProperty was just called")
+// GetPropertyWithContext calls GetPropertyWithContextFunc.
+func (mock *dbusBusObjectMock) GetPropertyWithContext(ctx context.Context, p string) (dbus.Variant, error) {
+	if mock.GetPropertyWithContextFunc == nil {
+		panic("dbusBusObjectMock.GetPropertyWithContextFunc: method is nil but dbusBusObject.GetPropertyWithContext was just called")
 	}
 	callInfo := struct {
-		P string
+		Ctx context.Context
+		P   string
 	}{
-		P: p,
+		Ctx: ctx,
+		P:   p,
 	}
-	mock.lockGetProperty.Lock()
-	mock.calls.GetProperty = append(mock.calls.GetProperty, callInfo)
-	mock.lockGetProperty.Unlock()
-	return mock.GetPropertyFunc(p)
+	mock.lockGetPropertyWithContext.Lock()
+	mock.calls.GetPropertyWithContext = append(mock.calls.GetPropertyWithContext, callInfo)
+	mock.lockGetPropertyWithContext.Unlock()
+	return mock.GetPropertyWithContextFunc(ctx, p)
 }
 
-// GetPropertyCalls gets all the calls that were made to GetProperty.
+// GetPropertyWithContextCalls gets all the calls that were made to GetPropertyWithContext.
 // Check the length with:
 //
-//	len(mockeddbusBusObject.GetPropertyCalls())
-func (mock *dbusBusObjectMock) GetPropertyCalls() []struct {
-	P string
+//	len(mockeddbusBusObject.GetPropertyWithContextCalls())
+func (mock *dbusBusObjectMock) GetPropertyWithContextCalls() []struct {
+	Ctx context.Context
+	P   string
 } {
 	var calls []struct {
-		P string
+		Ctx context.Context
+		P   string
 	}
-	mock.lockGetProperty.RLock()
-	calls = mock.calls.GetProperty
-	mock.lockGetProperty.RUnlock()
+	mock.lockGetPropertyWithContext.RLock()
+	calls = mock.calls.GetPropertyWithContext
+	mock.lockGetPropertyWithContext.RUnlock()
 	return calls
 }
 
-// SetProperty calls SetPropertyFunc.
-func (mock *dbusBusObjectMock) SetProperty(p string, v interface{}) error {
-	if mock.SetPropertyFunc == nil {
-		panic("dbusBusObjectMock.SetPropertyFunc: method is nil but dbusBusObject.SetProperty was just called")
+// SetPropertyWithContext calls SetPropertyWithContextFunc.
+func (mock *dbusBusObjectMock) SetPropertyWithContext(ctx context.Context, p string, v interface{}) error {
+	if mock.SetPropertyWithContextFunc == nil {
+		panic("dbusBusObjectMock.SetPropertyWithContextFunc: method is nil but dbusBusObject.SetPropertyWithContext was just called")
 	}
 	callInfo := struct {
-		P string
-		V interface{}
+		Ctx context.Context
+		P   string
+		V   interface{}
 	}{
-		P: p,
-		V: v,
+		Ctx: ctx,
+		P:   p,
+		V:   v,
 	}
-	mock.lockSetProperty.Lock()
-	mock.calls.SetProperty = append(mock.calls.SetProperty, callInfo)
-	mock.lockSetProperty.Unlock()
-	return mock.SetPropertyFunc(p, v)
+	mock.lockSetPropertyWithContext.Lock()
+	mock.calls.SetPropertyWithContext = append(mock.calls.SetPropertyWithContext, callInfo)
+	mock.lockSetPropertyWithContext.Unlock()
+	return mock.SetPropertyWithContextFunc(ctx, p, v)
 }
 
-// SetPropertyCalls gets all the calls that were made to SetProperty.
+// SetPropertyWithContextCalls gets all the calls that were made to SetPropertyWithContext.
 // Check the length with:
 //
-//	len(mockeddbusBusObject.SetPropertyCalls())
-func (mock *dbusBusObjectMock) SetPropertyCalls() []struct {
-	P string
-	V interface{}
+//	len(mockeddbusBusObject.SetPropertyWithContextCalls())
+func (mock *dbusBusObjectMock) SetPropertyWithContextCalls() []struct {
+	Ctx context.Context
+	P   string
+	V   interface{}
 } {
 	var calls []struct {
-		P string
-		V interface{}
+		Ctx context.Context
+		P   string
+		V   interface{}
 	}
-	mock.lockSetProperty.RLock()
-	calls = mock.calls.SetProperty
-	mock.lockSetProperty.RUnlock()
+	mock.lockSetPropertyWithContext.RLock()
+	calls = mock.calls.SetPropertyWithContext
+	mock.lockSetPropertyWithContext.RUnlock()
 	return calls
 }
@@ -27,6 +27,12 @@ var _ dbusConn = &dbusConnMock{}
 //			ObjectFunc: func(s string, objectPath dbus.ObjectPath) dbusBusObject {
 //				panic("mock out the Object method")
 //			},
+//			RemoveMatchSignalFunc: func(matchOptions ...dbus.MatchOption) error {
+//				panic("mock out the RemoveMatchSignal method")
+//			},
+//			RemoveSignalFunc: func(ch chan<- *dbus.Signal)  {
+//				panic("mock out the RemoveSignal method")
+//			},
 //			SignalFunc: func(ch chan<- *dbus.Signal)  {
 //				panic("mock out the Signal method")
 //			},
@@ -46,6 +52,12 @@ type dbusConnMock struct {
 	// ObjectFunc mocks the Object method.
 	ObjectFunc func(s string, objectPath dbus.ObjectPath) dbusBusObject
 
+	// RemoveMatchSignalFunc mocks the RemoveMatchSignal method.
+	RemoveMatchSignalFunc func(matchOptions ...dbus.MatchOption) error
+
+	// RemoveSignalFunc mocks the RemoveSignal method.
+	RemoveSignalFunc func(ch chan<- *dbus.Signal)
+
 	// SignalFunc mocks the Signal method.
 	SignalFunc func(ch chan<- *dbus.Signal)
 
@@ -66,16 +78,28 @@ type dbusConnMock struct {
 			// ObjectPath is the objectPath argument value.
 			ObjectPath dbus.ObjectPath
 		}
+		// RemoveMatchSignal holds details about calls to the RemoveMatchSignal method.
+		RemoveMatchSignal []struct {
+			// MatchOptions is the matchOptions argument value.
+			MatchOptions []dbus.MatchOption
+		}
+		// RemoveSignal holds details about calls to the RemoveSignal method.
+		RemoveSignal []struct {
+			// Ch is the ch argument value.
+			Ch chan<- *dbus.Signal
+		}
 		// Signal holds details about calls to the Signal method.
 		Signal []struct {
 			// Ch is the ch argument value.
 			Ch chan<- *dbus.Signal
 		}
 	}
-	lockAddMatchSignal sync.RWMutex
-	lockClose          sync.RWMutex
-	lockObject         sync.RWMutex
-	lockSignal         sync.RWMutex
+	lockAddMatchSignal    sync.RWMutex
+	lockClose             sync.RWMutex
+	lockObject            sync.RWMutex
+	lockRemoveMatchSignal sync.RWMutex
+	lockRemoveSignal      sync.RWMutex
+	lockSignal            sync.RWMutex
 }
 
 // AddMatchSignal calls AddMatchSignalFunc.
@@ -173,6 +197,70 @@ func (mock *dbusConnMock) ObjectCalls() []struct {
 	return calls
 }
 
+// RemoveMatchSignal calls RemoveMatchSignalFunc.
+func (mock *dbusConnMock) RemoveMatchSignal(matchOptions ...dbus.MatchOption) error {
+	if mock.RemoveMatchSignalFunc == nil {
+		panic("dbusConnMock.RemoveMatchSignalFunc: method is nil but dbusConn.RemoveMatchSignal was just called")
+	}
+	callInfo := struct {
+		MatchOptions []dbus.MatchOption
+	}{
+		MatchOptions: matchOptions,
+	}
+	mock.lockRemoveMatchSignal.Lock()
+	mock.calls.RemoveMatchSignal = append(mock.calls.RemoveMatchSignal, callInfo)
+	mock.lockRemoveMatchSignal.Unlock()
+	return mock.RemoveMatchSignalFunc(matchOptions...)
+}
+
+// RemoveMatchSignalCalls gets all the calls that were made to RemoveMatchSignal.
+// Check the length with:
+//
+//	len(mockeddbusConn.RemoveMatchSignalCalls())
+func (mock *dbusConnMock) RemoveMatchSignalCalls() []struct {
+	MatchOptions []dbus.MatchOption
+} {
+	var calls []struct {
+		MatchOptions []dbus.MatchOption
+	}
+	mock.lockRemoveMatchSignal.RLock()
+	calls = mock.calls.RemoveMatchSignal
+	mock.lockRemoveMatchSignal.RUnlock()
+	return calls
+}
+
+// RemoveSignal calls RemoveSignalFunc.
+func (mock *dbusConnMock) RemoveSignal(ch chan<- *dbus.Signal) {
+	if mock.RemoveSignalFunc == nil {
+		panic("dbusConnMock.RemoveSignalFunc: method is nil but dbusConn.RemoveSignal was just called")
+	}
+	callInfo := struct {
+		Ch chan<- *dbus.Signal
+	}{
+		Ch: ch,
+	}
+	mock.lockRemoveSignal.Lock()
+	mock.calls.RemoveSignal = append(mock.calls.RemoveSignal, callInfo)
+	mock.lockRemoveSignal.Unlock()
+	mock.RemoveSignalFunc(ch)
+}
+
+// RemoveSignalCalls gets all the calls that were made to RemoveSignal.
+// Check the length with:
+//
+//	len(mockeddbusConn.RemoveSignalCalls())
+func (mock *dbusConnMock) RemoveSignalCalls() []struct {
+	Ch chan<- *dbus.Signal
+} {
+	var calls []struct {
+		Ch chan<- *dbus.Signal
+	}
+	mock.lockRemoveSignal.RLock()
+	calls = mock.calls.RemoveSignal
+	mock.lockRemoveSignal.RUnlock()
+	return calls
+}
+
 // Signal calls SignalFunc.
 func (mock *dbusConnMock) Signal(ch chan<- *dbus.Signal) {
 	if mock.SignalFunc == nil {
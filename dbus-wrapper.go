@@ -1,6 +1,12 @@
 package mpris
 
-import "github.com/godbus/dbus/v5"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
 
 type dbusConnWrapper struct {
 	conn *dbus.Conn
@@ -16,26 +22,56 @@ func (w dbusConnWrapper) AddMatchSignal(options ...dbus.MatchOption) error {
 	return w.conn.AddMatchSignal(options...)
 }
 
+func (w dbusConnWrapper) RemoveMatchSignal(options ...dbus.MatchOption) error {
+	return w.conn.RemoveMatchSignal(options...)
+}
+
 func (w dbusConnWrapper) Signal(ch chan<- *dbus.Signal) {
 	w.conn.Signal(ch)
 }
 
+func (w dbusConnWrapper) RemoveSignal(ch chan<- *dbus.Signal) {
+	w.conn.RemoveSignal(ch)
+}
+
 type dbusBusObjectWrapper struct {
 	obj dbus.BusObject
 }
 
-func (w dbusBusObjectWrapper) Call(method string, flags dbus.Flags, args ...interface{}) dbusCall {
+func (w dbusBusObjectWrapper) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
 	return dbusCallWrapper{
-		call: w.obj.Call(method, flags, args),
+		call: w.obj.CallWithContext(ctx, method, flags, args...),
 	}
 }
 
-func (w dbusBusObjectWrapper) GetProperty(p string) (dbus.Variant, error) {
-	return w.obj.GetProperty(p)
+func (w dbusBusObjectWrapper) GetPropertyWithContext(ctx context.Context, p string) (dbus.Variant, error) {
+	iface, prop, err := splitProperty(p)
+	if err != nil {
+		return dbus.Variant{}, err
+	}
+
+	var v dbus.Variant
+	err = w.obj.CallWithContext(ctx, propertiesGetMethod, 0, iface, prop).Store(&v)
+	return v, err
 }
 
-func (w dbusBusObjectWrapper) SetProperty(p string, v interface{}) error {
-	return w.obj.SetProperty(p, v)
+func (w dbusBusObjectWrapper) SetPropertyWithContext(ctx context.Context, p string, v interface{}) error {
+	iface, prop, err := splitProperty(p)
+	if err != nil {
+		return err
+	}
+
+	return w.obj.CallWithContext(ctx, propertiesSetMethod, 0, iface, prop, v).Err
+}
+
+// splitProperty splits the given property in interface.member notation into interface and member.
+func splitProperty(p string) (string, string, error) {
+	i := strings.LastIndex(p, ".")
+	if i == -1 || i+1 == len(p) {
+		return "", "", fmt.Errorf("invalid property %q", p)
+	}
+
+	return p[:i], p[i+1:], nil
 }
 
 type dbusCallWrapper struct {
@@ -43,7 +79,7 @@ type dbusCallWrapper struct {
 }
 
 func (w dbusCallWrapper) Store(retvalues ...interface{}) error {
-	return w.call.Store(retvalues)
+	return w.call.Store(retvalues...)
 }
 
 func (w dbusConnWrapper) Close() error {
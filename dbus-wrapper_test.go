@@ -0,0 +1,43 @@
+package mpris
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBusObject is a dbus.BusObject which records the arguments of CallWithContext.
+type recordingBusObject struct {
+	dbus.BusObject
+	method string
+	args   []interface{}
+}
+
+func (o *recordingBusObject) CallWithContext(_ context.Context, method string, _ dbus.Flags, args ...interface{}) *dbus.Call {
+	o.method, o.args = method, args
+	return &dbus.Call{}
+}
+
+func TestDBusBusObjectWrapper_CallWithContext(t *testing.T) {
+	obj := &recordingBusObject{}
+	w := dbusBusObjectWrapper{obj: obj}
+
+	err := w.CallWithContext(context.Background(), "org.mpris.MediaPlayer2.Player.SetPosition", 0, dbus.ObjectPath("/track/1"), int64(42)).Store()
+	require.NoError(t, err)
+	assert.Equal(t, "org.mpris.MediaPlayer2.Player.SetPosition", obj.method)
+	assert.Equal(t, []interface{}{dbus.ObjectPath("/track/1"), int64(42)}, obj.args)
+}
+
+func TestDBusCallWrapper_Store(t *testing.T) {
+	w := dbusCallWrapper{call: &dbus.Call{Body: []interface{}{"org.mpris.MediaPlayer2.vlc", uint32(42)}}}
+
+	var name string
+	var pid uint32
+	err := w.Store(&name, &pid)
+	require.NoError(t, err)
+	assert.Equal(t, "org.mpris.MediaPlayer2.vlc", name)
+	assert.Equal(t, uint32(42), pid)
+}
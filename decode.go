@@ -0,0 +1,86 @@
+package mpris
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// decodeValue returns the value of v as T. An error wrapping ErrTypeNotParsable is returned when v does not contain a
+// T (e.g. because it is empty).
+func decodeValue[T any](property string, v dbus.Variant) (T, error) {
+	t, ok := v.Value().(T)
+	if !ok {
+		return t, notParsable(property, v, fmt.Sprintf("%T", t))
+	}
+
+	return t, nil
+}
+
+// decodeFloat64 is like decodeValue but converts all numeric dbus types to float64.
+func decodeFloat64(property string, v dbus.Variant) (float64, error) {
+	if f, ok := v.Value().(float64); ok {
+		return f, nil
+	}
+	if i, ok := toInt64(v.Value()); ok {
+		return float64(i), nil
+	}
+	if u, ok := v.Value().(uint64); ok {
+		return float64(u), nil
+	}
+
+	return 0, notParsable(property, v, "float64")
+}
+
+// decodeInt64 is like decodeValue but converts all numeric dbus types to int64. Doubles are truncated.
+func decodeInt64(property string, v dbus.Variant) (int64, error) {
+	if i, ok := toInt64(v.Value()); ok {
+		return i, nil
+	}
+	// float64(math.MaxInt64) is 2^63 which does not fit into an int64, so the upper bound is exclusive.
+	if f, ok := v.Value().(float64); ok && !math.IsNaN(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f), nil
+	}
+
+	return 0, notParsable(property, v, "int64")
+}
+
+// decodeUint32 is like decodeValue but converts all integer dbus types in range to uint32.
+func decodeUint32(property string, v dbus.Variant) (uint32, error) {
+	if i, ok := toInt64(v.Value()); ok && i >= 0 && i <= math.MaxUint32 {
+		return uint32(i), nil
+	}
+
+	return 0, notParsable(property, v, "uint32")
+}
+
+// toInt64 converts all integer dbus types which fit into an int64.
+func toInt64(value interface{}) (int64, bool) {
+	switch i := value.(type) {
+	case int:
+		return int64(i), true
+	case byte:
+		return int64(i), true
+	case int16:
+		return int64(i), true
+	case uint16:
+		return int64(i), true
+	case int32:
+		return int64(i), true
+	case uint32:
+		return int64(i), true
+	case int64:
+		return i, true
+	case uint64:
+		if i <= math.MaxInt64 {
+			return int64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+func notParsable(property string, v dbus.Variant, typ string) error {
+	return fmt.Errorf("property %q of type %q could not be parsed to %s: %w", property, v.Signature(), typ, ErrTypeNotParsable)
+}
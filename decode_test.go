@@ -0,0 +1,123 @@
+package mpris
+
+import (
+	"math"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFloat64(t *testing.T) {
+	tests := []struct {
+		name          string
+		given         dbus.Variant
+		expectedValue float64
+		expectedErr   string
+	}{
+		{name: "double", given: dbus.MakeVariant(0.5), expectedValue: 0.5},
+		{name: "int32", given: dbus.MakeVariant(int32(-1)), expectedValue: -1},
+		{name: "uint32", given: dbus.MakeVariant(uint32(2)), expectedValue: 2},
+		{name: "int64", given: dbus.MakeVariant(int64(3)), expectedValue: 3},
+		{name: "uint64", given: dbus.MakeVariant(uint64(math.MaxUint64)), expectedValue: math.MaxUint64},
+		{name: "byte", given: dbus.MakeVariant(byte(4)), expectedValue: 4},
+		{
+			name:        "string",
+			given:       dbus.MakeVariant("1.0"),
+			expectedErr: "property \"prop\" of type \"s\" could not be parsed to float64: the given type is not as expected",
+		}, {
+			name:        "empty",
+			expectedErr: "property \"prop\" of type \"\" could not be parsed to float64: the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := decodeFloat64("prop", tt.given)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedValue, v)
+		})
+	}
+}
+
+func TestDecodeInt64(t *testing.T) {
+	tests := []struct {
+		name          string
+		given         dbus.Variant
+		expectedValue int64
+		expectedErr   string
+	}{
+		{name: "int64", given: dbus.MakeVariant(int64(math.MaxInt64)), expectedValue: math.MaxInt64},
+		{name: "int32", given: dbus.MakeVariant(int32(-1)), expectedValue: -1},
+		{name: "uint32", given: dbus.MakeVariant(uint32(math.MaxUint32)), expectedValue: math.MaxUint32},
+		{name: "int16", given: dbus.MakeVariant(int16(-2)), expectedValue: -2},
+		{name: "uint16", given: dbus.MakeVariant(uint16(2)), expectedValue: 2},
+		{name: "double", given: dbus.MakeVariant(3.9), expectedValue: 3},
+		{name: "double min", given: dbus.MakeVariant(float64(math.MinInt64)), expectedValue: math.MinInt64},
+		{
+			name:        "double overflow",
+			given:       dbus.MakeVariant(float64(math.MaxInt64)),
+			expectedErr: "property \"prop\" of type \"d\" could not be parsed to int64: the given type is not as expected",
+		}, {
+			name:        "double NaN",
+			given:       dbus.MakeVariant(math.NaN()),
+			expectedErr: "property \"prop\" of type \"d\" could not be parsed to int64: the given type is not as expected",
+		}, {
+			name:        "uint64 overflow",
+			given:       dbus.MakeVariant(uint64(math.MaxUint64)),
+			expectedErr: "property \"prop\" of type \"t\" could not be parsed to int64: the given type is not as expected",
+		}, {
+			name:        "bool",
+			given:       dbus.MakeVariant(true),
+			expectedErr: "property \"prop\" of type \"b\" could not be parsed to int64: the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := decodeInt64("prop", tt.given)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedValue, v)
+		})
+	}
+}
+
+func TestDecodeUint32(t *testing.T) {
+	tests := []struct {
+		name          string
+		given         dbus.Variant
+		expectedValue uint32
+		expectedErr   string
+	}{
+		{name: "uint32", given: dbus.MakeVariant(uint32(math.MaxUint32)), expectedValue: math.MaxUint32},
+		{name: "int32", given: dbus.MakeVariant(int32(5)), expectedValue: 5},
+		{name: "int64", given: dbus.MakeVariant(int64(6)), expectedValue: 6},
+		{
+			name:        "negative",
+			given:       dbus.MakeVariant(int32(-1)),
+			expectedErr: "property \"prop\" of type \"i\" could not be parsed to uint32: the given type is not as expected",
+		}, {
+			name:        "overflow",
+			given:       dbus.MakeVariant(int64(math.MaxUint32 + 1)),
+			expectedErr: "property \"prop\" of type \"x\" could not be parsed to uint32: the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := decodeUint32("prop", tt.given)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedValue, v)
+		})
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	v, err := decodeValue[[]string]("prop", dbus.MakeVariant([]string{"a", "b"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, v)
+
+	_, err = decodeValue[[]string]("prop", dbus.MakeVariant([]dbus.ObjectPath{"/a"}))
+	assert.EqualError(t, err, "property \"prop\" of type \"ao\" could not be parsed to []string: the given type is not as expected")
+	assert.ErrorIs(t, err, ErrTypeNotParsable)
+}
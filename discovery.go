@@ -0,0 +1,105 @@
+package mpris
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	busNamePrefix           = "org.mpris.MediaPlayer2."
+	dbusDestination         = "org.freedesktop.DBus"
+	dbusObjectPath          = "/org/freedesktop/DBus"
+	dbusInterface           = "org.freedesktop.DBus"
+	dbusListNamesMethod     = dbusInterface + ".ListNames"
+	dbusGetNameOwnerMethod  = dbusInterface + ".GetNameOwner"
+	dbusErrorNameHasNoOwner = "org.freedesktop.DBus.Error.NameHasNoOwner"
+)
+
+// PlayerHandle identifies a media player which is available on the bus.
+// Use PlayerHandle.NewPlayer to create a Player from it.
+type PlayerHandle struct {
+	// BusName is the well-known bus name of the player. e.g. "org.mpris.MediaPlayer2.vlc.instance1234"
+	BusName string
+	// UniqueName is the unique connection name which currently owns BusName. e.g. ":1.42"
+	UniqueName string
+	// Name is the player part of BusName without prefix and instance suffix. e.g. "vlc"
+	Name string
+	// Instance is the instance suffix of BusName. e.g. ".instance1234". It is empty when the player does not use one.
+	Instance string
+}
+
+// NewPlayer returns a new Player for the handles BusName which is already connected to session-bus via
+// dbus.SessionBus. Don't forget to Player.Close() the player after use.
+func (h PlayerHandle) NewPlayer() (Player, error) {
+	return NewPlayer(h.BusName)
+}
+
+// ListPlayers returns a handle for every media player which is currently available on the session-bus.
+// The handles are sorted by their BusName.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/#Bus-Name-Policy
+func ListPlayers(ctx context.Context) ([]PlayerHandle, error) {
+	connection, err := dbusSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to session-bus: %w", err)
+	}
+
+	return listPlayers(ctx, &dbusConnWrapper{conn: connection})
+}
+
+func listPlayers(ctx context.Context, connection dbusConn) ([]PlayerHandle, error) {
+	var names []string
+	err := connection.Object(dbusDestination, dbusObjectPath).CallWithContext(ctx, dbusListNamesMethod, 0).Store(&names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list names: %w", err)
+	}
+
+	var handles []PlayerHandle
+	for _, name := range names {
+		if !strings.HasPrefix(name, busNamePrefix) {
+			continue
+		}
+
+		uniqueName, err := nameOwner(ctx, connection, name)
+		if err != nil {
+			if isDBusError(err, dbusErrorNameHasNoOwner) { // player vanished in the meantime
+				continue
+			}
+			return nil, err
+		}
+
+		handles = append(handles, newPlayerHandle(name, uniqueName))
+	}
+
+	sort.Slice(handles, func(i, j int) bool {
+		return handles[i].BusName < handles[j].BusName
+	})
+
+	return handles, nil
+}
+
+func newPlayerHandle(busName, uniqueName string) PlayerHandle {
+	name := strings.TrimPrefix(busName, busNamePrefix)
+	var instance string
+	if i := strings.Index(name, "."); i >= 0 {
+		name, instance = name[:i], name[i:]
+	}
+
+	return PlayerHandle{
+		BusName:    busName,
+		UniqueName: uniqueName,
+		Name:       name,
+		Instance:   instance,
+	}
+}
+
+func nameOwner(ctx context.Context, connection dbusConn, name string) (string, error) {
+	var owner string
+	err := connection.Object(dbusDestination, dbusObjectPath).CallWithContext(ctx, dbusGetNameOwnerMethod, 0, name).Store(&owner)
+	if err != nil {
+		return "", fmt.Errorf("failed to get owner of %q: %w", name, err)
+	}
+
+	return owner, nil
+}
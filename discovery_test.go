@@ -0,0 +1,120 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPlayers(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenNames      []string
+		listNamesErr    error
+		nameOwnerErrs   map[string]error
+		expectedHandles []PlayerHandle
+		expectedErr     string
+	}{
+		{
+			name: "happycase",
+			givenNames: []string{
+				"org.freedesktop.DBus",
+				":1.2",
+				"org.mpris.MediaPlayer2.vlc.instance1234",
+				"org.mpris.MediaPlayer2.spotify",
+				"org.mpris.MediaPlayer2Foo",
+			},
+			expectedHandles: []PlayerHandle{
+				{
+					BusName:    "org.mpris.MediaPlayer2.spotify",
+					UniqueName: ":1.org.mpris.MediaPlayer2.spotify",
+					Name:       "spotify",
+				}, {
+					BusName:    "org.mpris.MediaPlayer2.vlc.instance1234",
+					UniqueName: ":1.org.mpris.MediaPlayer2.vlc.instance1234",
+					Name:       "vlc",
+					Instance:   ".instance1234",
+				},
+			},
+		}, {
+			name:       "no players",
+			givenNames: []string{"org.freedesktop.DBus"},
+		}, {
+			name:         "list names error",
+			listNamesErr: errors.New("nope"),
+			expectedErr:  "failed to list names: nope",
+		}, {
+			name:       "name owner error",
+			givenNames: []string{"org.mpris.MediaPlayer2.vlc"},
+			nameOwnerErrs: map[string]error{
+				"org.mpris.MediaPlayer2.vlc": errors.New("nope"),
+			},
+			expectedErr: "failed to get owner of \"org.mpris.MediaPlayer2.vlc\": nope",
+		}, {
+			name:       "vanished player",
+			givenNames: []string{"org.mpris.MediaPlayer2.vlc", "org.mpris.MediaPlayer2.mpv"},
+			nameOwnerErrs: map[string]error{
+				"org.mpris.MediaPlayer2.vlc": dbus.Error{Name: "org.freedesktop.DBus.Error.NameHasNoOwner"},
+			},
+			expectedHandles: []PlayerHandle{
+				{
+					BusName:    "org.mpris.MediaPlayer2.mpv",
+					UniqueName: ":1.org.mpris.MediaPlayer2.mpv",
+					Name:       "mpv",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &dbusConnMock{
+				ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+					assert.Equal(t, "org.freedesktop.DBus", dest)
+					assert.Equal(t, dbus.ObjectPath("/org/freedesktop/DBus"), path)
+					return &dbusBusObjectMock{
+						CallWithContextFunc: func(_ context.Context, method string, _ dbus.Flags, args ...interface{}) dbusCall {
+							return &dbusCallMock{
+								StoreFunc: func(retvalues ...interface{}) error {
+									switch method {
+									case "org.freedesktop.DBus.ListNames":
+										*retvalues[0].(*[]string) = tt.givenNames
+										return tt.listNamesErr
+									case "org.freedesktop.DBus.GetNameOwner":
+										name := args[0].(string)
+										*retvalues[0].(*string) = ":1." + name
+										return tt.nameOwnerErrs[name]
+									}
+									t.Fatalf("unexpected method %q", method)
+									return nil
+								},
+							}
+						},
+					}
+				},
+			}
+
+			handles, err := listPlayers(context.Background(), mock)
+			require.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedHandles, handles)
+		})
+	}
+}
+
+func TestListPlayers_Error(t *testing.T) {
+	oldDbusSessionBus := dbusSessionBus
+	defer func() {
+		dbusSessionBus = oldDbusSessionBus
+	}()
+
+	dbusSessionBus = func() (conn *dbus.Conn, err error) {
+		return nil, errors.New("nope")
+	}
+
+	_, err := ListPlayers(context.Background())
+	assert.EqualError(t, err, "failed to connect to session-bus: nope")
+}
@@ -0,0 +1,40 @@
+package mpris
+
+import (
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+var (
+	// ErrSeekNotSupported indicates, that the player can not seek (the CanSeek property is false).
+	ErrSeekNotSupported = errors.New("the player can not seek")
+	// ErrPositionOutOfRange indicates, that the requested position is beyond the end of the track.
+	ErrPositionOutOfRange = errors.New("the position is out of range")
+	// ErrInvalidMetadata indicates, that the metadata does not comply with the specification.
+	ErrInvalidMetadata = errors.New("the metadata is invalid")
+	// ErrNameTaken indicates, that the requested bus name is already owned by another connection.
+	ErrNameTaken = errors.New("the bus name is already taken")
+	// ErrUnknownTrack indicates, that the track is not part of the tracklist.
+	ErrUnknownTrack = errors.New("the track is not part of the tracklist")
+)
+
+// dbusErrorName returns the name of the D-Bus error (e.g. "org.freedesktop.DBus.Error.ServiceUnknown") wrapped by err.
+func dbusErrorName(err error) (string, bool) {
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) {
+		return dbusErr.Name, true
+	}
+
+	var dbusErrPtr *dbus.Error
+	if errors.As(err, &dbusErrPtr) {
+		return dbusErrPtr.Name, true
+	}
+
+	return "", false
+}
+
+func isDBusError(err error, name string) bool {
+	n, ok := dbusErrorName(err)
+	return ok && n == name
+}
@@ -0,0 +1,48 @@
+package mpris
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBusErrorName(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedName string
+		expectedOk   bool
+	}{
+		{
+			name:         "dbus error",
+			err:          dbus.Error{Name: "org.freedesktop.DBus.Error.ServiceUnknown"},
+			expectedName: "org.freedesktop.DBus.Error.ServiceUnknown",
+			expectedOk:   true,
+		}, {
+			name:         "dbus error pointer",
+			err:          dbus.NewError("org.freedesktop.DBus.Error.NameHasNoOwner", nil),
+			expectedName: "org.freedesktop.DBus.Error.NameHasNoOwner",
+			expectedOk:   true,
+		}, {
+			name:         "wrapped dbus error",
+			err:          fmt.Errorf("wrapped: %w", dbus.Error{Name: "org.freedesktop.DBus.Error.NoReply"}),
+			expectedName: "org.freedesktop.DBus.Error.NoReply",
+			expectedOk:   true,
+		}, {
+			name: "other error",
+			err:  errors.New("nope"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := dbusErrorName(tt.err)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedOk, isDBusError(tt.err, tt.expectedName))
+		})
+	}
+}
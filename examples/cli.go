@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -11,7 +12,13 @@ import (
 )
 
 func main() {
-	p, err := mpris.NewPlayer("org.mpris.MediaPlayer2.vlc")
+	name, err := playerName()
+	if err != nil {
+		fmt.Printf("failed to find player: %s\n", err)
+		os.Exit(1)
+	}
+
+	p, err := mpris.NewPlayer(name)
 	if err != nil {
 		fmt.Printf("failed to create gompris.Player: %s\n", err)
 		os.Exit(1)
@@ -32,6 +39,23 @@ func main() {
 	}
 }
 
+// playerName returns the bus name given as first argument or the one of the first available player.
+func playerName() (string, error) {
+	if len(os.Args) > 1 {
+		return os.Args[1], nil
+	}
+
+	handles, err := mpris.ListPlayers(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if len(handles) == 0 {
+		return "", fmt.Errorf("no player available")
+	}
+
+	return handles[0].BusName, nil
+}
+
 func handleSetInput(p mpris.Player, reader *bufio.Reader, input string) error {
 	var err error
 	switch input {
@@ -62,7 +86,10 @@ func handleOpenURI(p mpris.Player, reader *bufio.Reader) error {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
 
-	p.OpenURI(strings.Trim(uri, "\n "))
+	err = p.OpenURI(strings.Trim(uri, "\n "))
+	if err != nil {
+		fmt.Printf("failed to open uri: %s\n", err)
+	}
 	return nil
 }
 
@@ -80,7 +107,10 @@ func handleSeek(p mpris.Player, reader *bufio.Reader) error {
 		return nil
 	}
 
-	p.SeekTo(offset)
+	err = p.SeekTo(offset)
+	if err != nil {
+		fmt.Printf("failed to seek: %s\n", err)
+	}
 	return nil
 }
 
@@ -165,7 +195,10 @@ func handleSetPosition(p mpris.Player, reader *bufio.Reader) error {
 		return nil
 	}
 
-	p.SetPosition("/not/used", position)
+	err = p.SetPosition("/not/used", position)
+	if err != nil {
+		fmt.Printf("failed to set position: %s\n", err)
+	}
 	return nil
 }
 
@@ -314,24 +347,36 @@ func handleInput(p mpris.Player, reader *bufio.Reader, input string) {
 		fmt.Printf("failed to handle set input: %s\n", err)
 	}
 
+	err = handleControlInput(p, input)
+	if err != nil {
+		fmt.Printf("failed to %s: %s\n", input, err)
+	}
+
 	switch input {
 	case "help":
 		printHelp()
 	case "quit":
 		os.Exit(0)
+	}
+}
+
+func handleControlInput(p mpris.Player, input string) error {
+	switch input {
 	case "next":
-		p.Next()
+		return p.Next()
 	case "previous":
-		p.Next()
+		return p.Previous()
 	case "pause":
-		p.Pause()
+		return p.Pause()
 	case "play-pause":
-		p.PlayPause()
+		return p.PlayPause()
 	case "stop":
-		p.Stop()
+		return p.Stop()
 	case "play":
-		p.Play()
+		return p.Play()
 	}
+
+	return nil
 }
 
 func printHelp() {
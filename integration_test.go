@@ -0,0 +1,474 @@
+package mpris
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBusConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// startTestBus starts a private dbus-daemon for the test and returns its address. The test is skipped when
+// dbus-daemon is not installed or when running in short mode.
+func startTestBus(t *testing.T) string {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("skipping integration test: dbus-daemon is not installed")
+	}
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "bus.conf")
+	err = os.WriteFile(config, []byte(strings.Replace(testBusConfig, "%s", filepath.Join(dir, "bus"), 1)), 0o600)
+	require.NoError(t, err)
+
+	cmd := exec.Command(daemon, "--config-file="+config, "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	return strings.TrimSpace(address)
+}
+
+func connectTestBus(t *testing.T, address string) *dbus.Conn {
+	conn, err := dbus.Connect(address)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+type testPlayerCall struct {
+	method string
+	args   []interface{}
+}
+
+// testPlayer is a minimal media player exported on a test bus.
+type testPlayer struct {
+	conn  *dbus.Conn
+	props *prop.Properties
+
+	mu    sync.Mutex
+	calls []testPlayerCall
+}
+
+func exportTestPlayer(t *testing.T, address, name string) *testPlayer {
+	tp := &testPlayer{conn: connectTestBus(t, address)}
+
+	err := tp.conn.ExportMethodTable(map[string]interface{}{
+		"Next": func() *dbus.Error {
+			tp.record("Next")
+			return nil
+		},
+		"Seek": func(offset int64) *dbus.Error {
+			tp.record("Seek", offset)
+			return nil
+		},
+		"SetPosition": func(trackID dbus.ObjectPath, position int64) *dbus.Error {
+			tp.record("SetPosition", trackID, position)
+			return nil
+		},
+		"OpenUri": func(uri string) *dbus.Error {
+			tp.record("OpenUri", uri)
+			return nil
+		},
+	}, playerObjectPath, playerInterface)
+	require.NoError(t, err)
+
+	err = tp.conn.ExportMethodTable(map[string]interface{}{
+		"GetTracksMetadata": func(trackIDs []dbus.ObjectPath) ([]map[string]dbus.Variant, *dbus.Error) {
+			tp.record("GetTracksMetadata", trackIDs)
+			metadata := make([]map[string]dbus.Variant, len(trackIDs))
+			for i, trackID := range trackIDs {
+				metadata[i] = map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(trackID)}
+			}
+			return metadata, nil
+		},
+	}, playerObjectPath, trackListInterface)
+	require.NoError(t, err)
+
+	tp.props, err = prop.Export(tp.conn, playerObjectPath, prop.Map{
+		playerInterface: {
+			"PlaybackStatus": {Value: "Playing", Emit: prop.EmitTrue},
+			"Rate":           {Value: 1.0, Emit: prop.EmitTrue},
+			"Metadata": {Value: map[string]dbus.Variant{
+				"mpris:trackid":     dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":      dbus.MakeVariant(int64(60000000)),
+				"xesam:trackNumber": dbus.MakeVariant(int32(3)),
+			}, Emit: prop.EmitTrue},
+			"Volume":     {Value: 0.5, Writable: true, Emit: prop.EmitTrue},
+			"Position":   {Value: int64(10000000), Emit: prop.EmitFalse},
+			"CanSeek":    {Value: true, Emit: prop.EmitTrue},
+			"CanControl": {Value: true, Emit: prop.EmitConst},
+		},
+	})
+	require.NoError(t, err)
+
+	reply, err := tp.conn.RequestName(name, dbus.NameFlagDoNotQueue)
+	require.NoError(t, err)
+	require.Equal(t, dbus.RequestNameReplyPrimaryOwner, reply)
+
+	return tp
+}
+
+func (tp *testPlayer) record(method string, args ...interface{}) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.calls = append(tp.calls, testPlayerCall{method: method, args: args})
+}
+
+func (tp *testPlayer) recordedCalls() []testPlayerCall {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]testPlayerCall(nil), tp.calls...)
+}
+
+func TestIntegration_Player(t *testing.T) {
+	address := startTestBus(t)
+	tp := exportTestPlayer(t, address, "org.mpris.MediaPlayer2.test")
+	p := NewPlayerWithConnection("org.mpris.MediaPlayer2.test", connectTestBus(t, address))
+
+	t.Run("methods", func(t *testing.T) {
+		require.NoError(t, p.Next())
+		require.NoError(t, p.SeekTo(-5000000))
+		require.NoError(t, p.SetPosition("/track/1", 20000000))
+		require.NoError(t, p.OpenURI("file:///music.mp3"))
+		require.NoError(t, p.Seek(15*time.Second))
+		require.NoError(t, p.SetPositionDuration("/track/1", -time.Second))
+
+		assert.Equal(t, []testPlayerCall{
+			{method: "Next"},
+			{method: "Seek", args: []interface{}{int64(-5000000)}},
+			{method: "SetPosition", args: []interface{}{dbus.ObjectPath("/track/1"), int64(20000000)}},
+			{method: "OpenUri", args: []interface{}{"file:///music.mp3"}},
+			{method: "Seek", args: []interface{}{int64(15000000)}},
+			{method: "SetPosition", args: []interface{}{dbus.ObjectPath("/track/1"), int64(0)}},
+		}, tp.recordedCalls())
+	})
+
+	t.Run("method with return values", func(t *testing.T) {
+		metadata, err := p.TrackList().GetTracksMetadata([]dbus.ObjectPath{"/track/1", "/track/2"})
+		require.NoError(t, err)
+		assert.Equal(t, []Metadata{
+			{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1"))},
+			{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/2"))},
+		}, metadata)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		err := p.Previous()
+		require.Error(t, err)
+		name, ok := dbusErrorName(err)
+		assert.True(t, ok)
+		assert.Equal(t, "org.freedesktop.DBus.Error.UnknownMethod", name)
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		status, err := p.PlaybackStatus()
+		require.NoError(t, err)
+		assert.Equal(t, PlaybackStatusPlaying, status)
+
+		md, err := p.Metadata()
+		require.NoError(t, err)
+		trackNumber, err := md.XESAMTrackNumber()
+		require.NoError(t, err)
+		assert.Equal(t, 3, trackNumber)
+
+		require.NoError(t, p.SetVolume(0.25))
+		volume, err := p.Volume()
+		require.NoError(t, err)
+		assert.Equal(t, 0.25, volume)
+
+		err = p.SetRate(2)
+		name, _ := dbusErrorName(err)
+		assert.Equal(t, "org.freedesktop.DBus.Properties.Error.ReadOnly", name)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		state, err := p.Snapshot()
+		require.NoError(t, err)
+		assert.Equal(t, PlaybackStatusPlaying, state.PlaybackStatus)
+		assert.Equal(t, 10*time.Second, state.Position)
+		assert.True(t, state.CanControl)
+	})
+
+	t.Run("signals", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		positions, err := p.SeekedDuration(ctx)
+		require.NoError(t, err)
+		changes, err := p.Changes(ctx)
+		require.NoError(t, err)
+
+		err = tp.conn.Emit(playerObjectPath, playerInterface+"."+playerSeekedMember, int64(42000000))
+		require.NoError(t, err)
+		assert.Equal(t, 42*time.Second, <-positions)
+
+		tp.props.SetMust(playerInterface, "PlaybackStatus", "Paused")
+		change := <-changes
+		require.NotNil(t, change.PlaybackStatus)
+		assert.Equal(t, PlaybackStatusPaused, *change.PlaybackStatus)
+	})
+}
+
+func TestIntegration_Discovery(t *testing.T) {
+	address := startTestBus(t)
+
+	oldDbusSessionBus := dbusSessionBus
+	defer func() {
+		dbusSessionBus = oldDbusSessionBus
+	}()
+	dbusSessionBus = func() (*dbus.Conn, error) {
+		return dbus.Connect(address)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := WatchPlayers(ctx)
+	require.NoError(t, err)
+
+	tp := exportTestPlayer(t, address, "org.mpris.MediaPlayer2.test.instance1")
+
+	event := <-events
+	added, ok := event.(PlayerAdded)
+	require.True(t, ok, "unexpected event %#v", event)
+	assert.Equal(t, "test", added.Handle.Name)
+	assert.Equal(t, ".instance1", added.Handle.Instance)
+	assert.Equal(t, tp.conn.Names()[0], added.Handle.UniqueName)
+
+	handles, err := ListPlayers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []PlayerHandle{added.Handle}, handles)
+
+	require.NoError(t, tp.conn.Close())
+	event = <-events
+	removed, ok := event.(PlayerRemoved)
+	require.True(t, ok, "unexpected event %#v", event)
+	assert.Equal(t, added.Handle, removed.Handle)
+}
+
+func TestIntegration_NoPlayer(t *testing.T) {
+	address := startTestBus(t)
+	p := NewPlayerWithConnection("org.mpris.MediaPlayer2.missing", connectTestBus(t, address))
+
+	err := p.Play()
+	var dbusErr dbus.Error
+	require.True(t, errors.As(err, &dbusErr), "unexpected error %v", err)
+	assert.Equal(t, "org.freedesktop.DBus.Error.ServiceUnknown", dbusErr.Name)
+}
+
+func TestIntegration_Server(t *testing.T) {
+	address := startTestBus(t)
+	h := &testHandler{}
+	s, err := NewServerWithConnection("test", h, newTestServerState(true), connectTestBus(t, address))
+	require.NoError(t, err)
+	p := NewPlayerWithConnection(s.BusName(), connectTestBus(t, address))
+
+	t.Run("methods", func(t *testing.T) {
+		require.NoError(t, p.Raise())
+		require.NoError(t, p.Next())
+		require.NoError(t, p.SeekTo(-5000000))
+		require.NoError(t, p.SetPosition("/track/1", 20000000))
+		require.NoError(t, p.SetPosition("/track/2", 20000000))
+		require.NoError(t, p.OpenURI("file:///music.mp3"))
+
+		calls, args := h.recorded()
+		assert.Equal(t, []string{"Raise", "Next", "Seek", "SetPosition", "OpenURI"}, calls)
+		assert.Equal(t, []interface{}{-5 * time.Second, dbus.ObjectPath("/track/1"), 20 * time.Second, "file:///music.mp3"}, args)
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		identity, err := p.Identity()
+		require.NoError(t, err)
+		assert.Equal(t, "Test Player", identity)
+
+		state, err := p.Snapshot()
+		require.NoError(t, err)
+		expected := newTestServerState(true).PlayerState
+		expected.Position = 1500 * time.Millisecond
+		assert.Equal(t, expected, state)
+
+		err = p.SetLoopStatus("Forever")
+		name, _ := dbusErrorName(err)
+		assert.Equal(t, "org.freedesktop.DBus.Error.InvalidArgs", name)
+		_, err = p.Shuffle()
+		name, _ = dbusErrorName(err)
+		assert.Equal(t, "org.freedesktop.DBus.Error.UnknownProperty", name)
+	})
+
+	t.Run("signals", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		changes, err := p.Changes(ctx)
+		require.NoError(t, err)
+		positions, err := p.SeekedDuration(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, s.Update(func(state *ServerState) {
+			state.PlaybackStatus = PlaybackStatusPaused
+			state.CanGoNext = false
+		}))
+		change := <-changes
+		require.NotNil(t, change.PlaybackStatus)
+		assert.Equal(t, PlaybackStatusPaused, *change.PlaybackStatus)
+		require.NotNil(t, change.CanGoNext)
+		assert.False(t, *change.CanGoNext)
+
+		require.NoError(t, p.SetVolume(0.25))
+		change = <-changes
+		require.NotNil(t, change.Volume)
+		assert.Equal(t, 0.25, *change.Volume)
+		assert.Equal(t, 0.25, s.State().Volume)
+
+		require.NoError(t, s.EmitSeeked(42*time.Second))
+		assert.Equal(t, 42*time.Second, <-positions)
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		conn := connectTestBus(t, address)
+		node, err := introspect.Call(conn.Object(s.BusName(), playerObjectPath))
+		require.NoError(t, err)
+		var ifaces []string
+		for _, iface := range node.Interfaces {
+			ifaces = append(ifaces, iface.Name)
+		}
+		assert.Subset(t, ifaces, []string{"org.freedesktop.DBus.Properties", "org.mpris.MediaPlayer2", "org.mpris.MediaPlayer2.Player"})
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		_, err := NewServerWithConnection("test", &testHandler{}, ServerState{}, connectTestBus(t, address))
+		assert.ErrorIs(t, err, ErrNameTaken)
+	})
+
+	t.Run("discovery and close", func(t *testing.T) {
+		handles, err := listPlayers(context.Background(), &dbusConnWrapper{conn: connectTestBus(t, address)})
+		require.NoError(t, err)
+		require.Len(t, handles, 1)
+		assert.Equal(t, "test", handles[0].Name)
+
+		require.NoError(t, s.Close())
+		err = p.Play()
+		name, _ := dbusErrorName(err)
+		assert.Equal(t, "org.freedesktop.DBus.Error.ServiceUnknown", name)
+	})
+}
+
+func TestIntegration_ServerTrackList(t *testing.T) {
+	address := startTestBus(t)
+	h := &testTrackListHandler{}
+	s, err := NewServerWithConnection("test", h, newTestServerState(true), connectTestBus(t, address))
+	require.NoError(t, err)
+	defer s.Close()
+	p := NewPlayerWithConnection(s.BusName(), connectTestBus(t, address))
+	trackList := p.TrackList()
+
+	hasTrackList, err := p.HasTrackList()
+	require.NoError(t, err)
+	assert.True(t, hasTrackList)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := trackList.Events(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, s.TrackList().Replace([]Metadata{testTrack("/track/1"), testTrack("/track/2")}, "/track/1"))
+	assert.Equal(t, TrackListReplaced{Tracks: []dbus.ObjectPath{"/track/1", "/track/2"}, CurrentTrack: "/track/1"}, <-events)
+	require.NoError(t, s.TrackList().Add(testTrack("/track/3"), "/track/1"))
+	assert.Equal(t, TrackAdded{Metadata: testTrack("/track/3"), AfterTrack: "/track/1"}, <-events)
+
+	tracks, err := trackList.Tracks()
+	require.NoError(t, err)
+	assert.Equal(t, []dbus.ObjectPath{"/track/1", "/track/3", "/track/2"}, tracks)
+	metadata, err := trackList.GetTracksMetadata([]dbus.ObjectPath{"/track/3", "/track/2"})
+	require.NoError(t, err)
+	assert.Equal(t, []Metadata{testTrack("/track/3"), testTrack("/track/2")}, metadata)
+
+	require.NoError(t, s.TrackList().SetCanEditTracks(true))
+	require.NoError(t, trackList.RemoveTrack("/track/1"))
+	assert.Equal(t, TrackRemoved{TrackID: "/track/1"}, <-events)
+	require.NoError(t, trackList.GoTo("/track/2"))
+	require.NoError(t, trackList.GoTo("/track/9"))
+
+	calls, _ := h.recorded()
+	assert.Equal(t, []string{"RemoveTrack", "GoTo"}, calls)
+}
+
+func TestIntegration_ServerPlaylists(t *testing.T) {
+	address := startTestBus(t)
+	h := &testPlaylistsHandler{playlists: newTestPlaylists()}
+	s, err := NewServerWithConnection("test", h, newTestServerState(true), connectTestBus(t, address))
+	require.NoError(t, err)
+	defer s.Close()
+	playlists := NewPlayerWithConnection(s.BusName(), connectTestBus(t, address)).Playlists()
+
+	count, err := playlists.PlaylistCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), count)
+	orderings, err := playlists.Orderings()
+	require.NoError(t, err)
+	assert.Equal(t, []PlaylistOrdering{PlaylistOrderingAlphabetical, PlaylistOrderingUserDefined}, orderings)
+	active, err := playlists.ActivePlaylist()
+	require.NoError(t, err)
+	assert.False(t, active.Valid)
+
+	page, err := playlists.GetPlaylists(1, 2, PlaylistOrderingAlphabetical, true)
+	require.NoError(t, err)
+	assert.Equal(t, []Playlist{{ID: "/playlist/b", Name: "b"}, {ID: "/playlist/a", Name: "a"}}, page)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	changed, err := playlists.PlaylistChanged(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, playlists.ActivatePlaylist("/playlist/c"))
+	active, err = playlists.ActivePlaylist()
+	require.NoError(t, err)
+	assert.Equal(t, MaybePlaylist{Valid: true, Playlist: Playlist{ID: "/playlist/c", Name: "C", Icon: "file:///c.png"}}, active)
+
+	renamed := newTestPlaylists()
+	renamed[1].Name = "Cc"
+	h.setPlaylists(renamed)
+	require.NoError(t, s.Playlists().Refresh())
+	assert.Equal(t, Playlist{ID: "/playlist/c", Name: "Cc", Icon: "file:///c.png"}, <-changed)
+
+	err = playlists.ActivatePlaylist("/playlist/z")
+	name, _ := dbusErrorName(err)
+	assert.Equal(t, "org.freedesktop.DBus.Error.InvalidArgs", name)
+}
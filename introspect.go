@@ -0,0 +1,142 @@
+package mpris
+
+import "github.com/godbus/dbus/v5/introspect"
+
+// rootIntrospectData describes the interface org.mpris.MediaPlayer2 as exported by Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html
+var rootIntrospectData = introspect.Interface{
+	Name: rootInterface,
+	Methods: []introspect.Method{
+		{Name: "Raise"},
+		{Name: "Quit"},
+	},
+	Properties: []introspect.Property{
+		{Name: "CanQuit", Type: "b", Access: "read"},
+		{Name: "Fullscreen", Type: "b", Access: "readwrite"},
+		{Name: "CanSetFullscreen", Type: "b", Access: "read"},
+		{Name: "CanRaise", Type: "b", Access: "read"},
+		{Name: "HasTrackList", Type: "b", Access: "read"},
+		{Name: "Identity", Type: "s", Access: "read"},
+		{Name: "DesktopEntry", Type: "s", Access: "read"},
+		{Name: "SupportedUriSchemes", Type: "as", Access: "read"},
+		{Name: "SupportedMimeTypes", Type: "as", Access: "read"},
+	},
+}
+
+// playerIntrospectData describes the interface org.mpris.MediaPlayer2.Player as exported by Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html
+var playerIntrospectData = introspect.Interface{
+	Name: playerInterface,
+	Methods: []introspect.Method{
+		{Name: "Next"},
+		{Name: "Previous"},
+		{Name: "Pause"},
+		{Name: "PlayPause"},
+		{Name: "Stop"},
+		{Name: "Play"},
+		{Name: "Seek", Args: []introspect.Arg{
+			{Name: "Offset", Type: "x", Direction: "in"},
+		}},
+		{Name: "SetPosition", Args: []introspect.Arg{
+			{Name: "TrackId", Type: "o", Direction: "in"},
+			{Name: "Position", Type: "x", Direction: "in"},
+		}},
+		{Name: "OpenUri", Args: []introspect.Arg{
+			{Name: "Uri", Type: "s", Direction: "in"},
+		}},
+	},
+	Properties: []introspect.Property{
+		{Name: "PlaybackStatus", Type: "s", Access: "read"},
+		{Name: "LoopStatus", Type: "s", Access: "readwrite"},
+		{Name: "Rate", Type: "d", Access: "readwrite"},
+		{Name: "Shuffle", Type: "b", Access: "readwrite"},
+		{Name: "Metadata", Type: "a{sv}", Access: "read"},
+		{Name: "Volume", Type: "d", Access: "readwrite"},
+		{Name: "Position", Type: "x", Access: "read"},
+		{Name: "MinimumRate", Type: "d", Access: "read"},
+		{Name: "MaximumRate", Type: "d", Access: "read"},
+		{Name: "CanGoNext", Type: "b", Access: "read"},
+		{Name: "CanGoPrevious", Type: "b", Access: "read"},
+		{Name: "CanPlay", Type: "b", Access: "read"},
+		{Name: "CanPause", Type: "b", Access: "read"},
+		{Name: "CanSeek", Type: "b", Access: "read"},
+		{Name: "CanControl", Type: "b", Access: "read"},
+	},
+	Signals: []introspect.Signal{
+		{Name: "Seeked", Args: []introspect.Arg{
+			{Name: "Position", Type: "x"},
+		}},
+	},
+}
+
+// trackListIntrospectData describes the interface org.mpris.MediaPlayer2.TrackList as exported by Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html
+var trackListIntrospectData = introspect.Interface{
+	Name: trackListInterface,
+	Methods: []introspect.Method{
+		{Name: "GetTracksMetadata", Args: []introspect.Arg{
+			{Name: "TrackIds", Type: "ao", Direction: "in"},
+			{Name: "Metadata", Type: "aa{sv}", Direction: "out"},
+		}},
+		{Name: "AddTrack", Args: []introspect.Arg{
+			{Name: "Uri", Type: "s", Direction: "in"},
+			{Name: "AfterTrack", Type: "o", Direction: "in"},
+			{Name: "SetAsCurrent", Type: "b", Direction: "in"},
+		}},
+		{Name: "RemoveTrack", Args: []introspect.Arg{
+			{Name: "TrackId", Type: "o", Direction: "in"},
+		}},
+		{Name: "GoTo", Args: []introspect.Arg{
+			{Name: "TrackId", Type: "o", Direction: "in"},
+		}},
+	},
+	Properties: []introspect.Property{
+		{Name: "Tracks", Type: "ao", Access: "read"},
+		{Name: "CanEditTracks", Type: "b", Access: "read"},
+	},
+	Signals: []introspect.Signal{
+		{Name: "TrackListReplaced", Args: []introspect.Arg{
+			{Name: "Tracks", Type: "ao"},
+			{Name: "CurrentTrack", Type: "o"},
+		}},
+		{Name: "TrackAdded", Args: []introspect.Arg{
+			{Name: "Metadata", Type: "a{sv}"},
+			{Name: "AfterTrack", Type: "o"},
+		}},
+		{Name: "TrackRemoved", Args: []introspect.Arg{
+			{Name: "TrackId", Type: "o"},
+		}},
+		{Name: "TrackMetadataChanged", Args: []introspect.Arg{
+			{Name: "TrackId", Type: "o"},
+			{Name: "Metadata", Type: "a{sv}"},
+		}},
+	},
+}
+
+// playlistsIntrospectData describes the interface org.mpris.MediaPlayer2.Playlists as exported by Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html
+var playlistsIntrospectData = introspect.Interface{
+	Name: playlistsInterface,
+	Methods: []introspect.Method{
+		{Name: "ActivatePlaylist", Args: []introspect.Arg{
+			{Name: "PlaylistId", Type: "o", Direction: "in"},
+		}},
+		{Name: "GetPlaylists", Args: []introspect.Arg{
+			{Name: "Index", Type: "u", Direction: "in"},
+			{Name: "MaxCount", Type: "u", Direction: "in"},
+			{Name: "Order", Type: "s", Direction: "in"},
+			{Name: "ReverseOrder", Type: "b", Direction: "in"},
+			{Name: "Playlists", Type: "a(oss)", Direction: "out"},
+		}},
+	},
+	Properties: []introspect.Property{
+		{Name: "PlaylistCount", Type: "u", Access: "read"},
+		{Name: "Orderings", Type: "as", Access: "read"},
+		{Name: "ActivePlaylist", Type: "(b(oss))", Access: "read"},
+	},
+	Signals: []introspect.Signal{
+		{Name: "PlaylistChanged", Args: []introspect.Arg{
+			{Name: "Playlist", Type: "(oss)"},
+		}},
+	},
+}
@@ -0,0 +1,386 @@
+package mpris
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// MarshalJSON encodes the metadata as JSON object. Known fields (see Track) of the type defined by the specification
+// are encoded as natural JSON values: object paths become strings, numbers become numbers and arrays become arrays.
+// All other fields are encoded as object containing their dbus signature and value (e.g. {"sig":"o","value":"/a"}),
+// nested variants are encoded the same way. This keeps the types of all fields when decoding the metadata again.
+func (md Metadata) MarshalJSON() ([]byte, error) {
+	if md == nil {
+		return []byte("null"), nil
+	}
+
+	values := make(map[string]interface{}, len(md))
+	for key, v := range md {
+		if typ, ok := metadataTypes[key]; ok && reflect.TypeOf(v.Value()) == typ {
+			values[key] = jsonValue(reflect.ValueOf(v.Value()), false)
+			continue
+		}
+		values[key] = jsonValue(reflect.ValueOf(v), true)
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes metadata encoded by MarshalJSON. Fields encoded with their signature get their original dbus
+// types. Other known fields (see Track) get the dbus types defined by the specification (e.g. int32 for
+// "xesam:trackNumber"). The types of other unknown fields are derived from their JSON values: integers become int64,
+// other numbers float64, arrays of strings []string, other arrays []dbus.Variant and objects map[string]dbus.Variant.
+// Null values are omitted.
+func (md *Metadata) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		*md = nil
+		return nil
+	}
+
+	m := Metadata{}
+	for key, value := range raw {
+		if typed, ok := parseTypedJSON(value); ok {
+			v, err := variantFromJSON(typed)
+			if err != nil {
+				return fmt.Errorf("failed to decode %q: %w", key, err)
+			}
+			m[key] = v
+			continue
+		}
+
+		typ, ok := metadataTypes[key]
+		if !ok {
+			v, err := unmarshalUnknownJSON(value)
+			if err != nil {
+				return fmt.Errorf("failed to decode %q: %w", key, err)
+			}
+			if v != nil {
+				m[key] = dbus.MakeVariant(v)
+			}
+			continue
+		}
+
+		if string(value) == "null" {
+			continue
+		}
+		v := reflect.New(typ)
+		err := json.Unmarshal(value, v.Interface())
+		if err != nil {
+			return fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		m[key] = dbus.MakeVariant(v.Elem().Interface())
+	}
+
+	*md = m
+	return nil
+}
+
+// typedJSON is the JSON encoding of a variant which keeps its dbus signature.
+type typedJSON struct {
+	Sig   string          `json:"sig"`
+	Value json.RawMessage `json:"value"`
+}
+
+// parseTypedJSON returns the typedJSON encoded in data. ok is false if data is not an object consisting of exactly the
+// keys "sig" and "value".
+func parseTypedJSON(data []byte) (typedJSON, bool) {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil || len(raw) != 2 || raw["value"] == nil {
+		return typedJSON{}, false
+	}
+
+	var typed typedJSON
+	if json.Unmarshal(data, &typed) != nil {
+		return typedJSON{}, false
+	}
+
+	return typed, true
+}
+
+func variantFromJSON(typed typedJSON) (dbus.Variant, error) {
+	sig, err := dbus.ParseSignature(typed.Sig)
+	if err != nil {
+		return dbus.Variant{}, err
+	}
+	if sigs, err := splitSignature(typed.Sig); err != nil || len(sigs) != 1 {
+		return dbus.Variant{}, fmt.Errorf("signature %q is not a single complete type", typed.Sig)
+	}
+
+	v, err := valueFromJSON(typed.Sig, typed.Value)
+	if err != nil {
+		return dbus.Variant{}, err
+	}
+
+	return dbus.MakeVariantWithSignature(v.Interface(), sig), nil
+}
+
+// valueFromJSON decodes the JSON value encoded by jsonValue into the go type of the given single complete dbus type.
+func valueFromJSON(sig string, data json.RawMessage) (reflect.Value, error) {
+	typ, err := signatureType(sig)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	switch {
+	case sig == "v":
+		var typed typedJSON
+		err := json.Unmarshal(data, &typed)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v, err := variantFromJSON(typed)
+		return reflect.ValueOf(v), err
+	case sig == "g":
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v, err := dbus.ParseSignature(s)
+		return reflect.ValueOf(v), err
+	case strings.HasPrefix(sig, "a{"):
+		var raw map[string]json.RawMessage
+		err := json.Unmarshal(data, &raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		keySig, valueSig := sig[2:3], sig[3:len(sig)-1]
+		m := reflect.MakeMapWithSize(typ, len(raw))
+		for k, e := range raw {
+			key, err := valueFromJSON(keySig, jsonKey(keySig, k))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value, err := valueFromJSON(valueSig, e)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			m.SetMapIndex(key, value)
+		}
+		return m, nil
+	case sig[0] == 'a' || sig[0] == '(':
+		var raw []json.RawMessage
+		err := json.Unmarshal(data, &raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		elemSigs := []string{sig[1:]}
+		if sig[0] == '(' {
+			elemSigs, err = splitSignature(sig[1 : len(sig)-1])
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if len(elemSigs) != len(raw) {
+				return reflect.Value{}, fmt.Errorf("struct %q has %d fields instead of %d", sig, len(raw), len(elemSigs))
+			}
+		}
+		s := reflect.MakeSlice(typ, len(raw), len(raw))
+		for i, e := range raw {
+			value, err := valueFromJSON(elemSigs[min(i, len(elemSigs)-1)], e)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s.Index(i).Set(value)
+		}
+		return s, nil
+	}
+
+	v := reflect.New(typ)
+	err = json.Unmarshal(data, v.Interface())
+	return v.Elem(), err
+}
+
+// jsonKey returns the JSON value of the given key of a dict as keys of JSON objects are always strings.
+func jsonKey(keySig, key string) json.RawMessage {
+	switch keySig {
+	case "s", "o", "g":
+		data, _ := json.Marshal(key)
+		return data
+	}
+	return json.RawMessage(key)
+}
+
+// signatureTypes contains the go types of the basic dbus types.
+var signatureTypes = map[byte]reflect.Type{
+	'y': reflect.TypeOf(byte(0)),
+	'b': reflect.TypeOf(false),
+	'n': reflect.TypeOf(int16(0)),
+	'q': reflect.TypeOf(uint16(0)),
+	'i': reflect.TypeOf(int32(0)),
+	'u': reflect.TypeOf(uint32(0)),
+	'x': reflect.TypeOf(int64(0)),
+	't': reflect.TypeOf(uint64(0)),
+	'd': reflect.TypeOf(float64(0)),
+	's': reflect.TypeOf(""),
+	'o': reflect.TypeOf(dbus.ObjectPath("")),
+	'g': reflect.TypeOf(dbus.Signature{}),
+	'v': reflect.TypeOf(dbus.Variant{}),
+}
+
+// signatureType returns the go type used by godbus for the given single complete dbus type. Structs are represented
+// as []interface{}.
+func signatureType(sig string) (reflect.Type, error) {
+	switch {
+	case sig == "":
+		return nil, errors.New("empty signature")
+	case len(sig) == 1 && signatureTypes[sig[0]] != nil:
+		return signatureTypes[sig[0]], nil
+	case strings.HasPrefix(sig, "a{") && strings.HasSuffix(sig, "}") && len(sig) > 4:
+		key, err := signatureType(sig[2:3])
+		if err != nil {
+			return nil, err
+		}
+		value, err := signatureType(sig[3 : len(sig)-1])
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(key, value), nil
+	case sig[0] == 'a':
+		elem, err := signatureType(sig[1:])
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+	case sig[0] == '(' && strings.HasSuffix(sig, ")"):
+		return reflect.TypeOf([]interface{}{}), nil
+	}
+
+	return nil, fmt.Errorf("unsupported signature %q", sig)
+}
+
+// splitSignature splits the given signature into its single complete types.
+func splitSignature(sig string) ([]string, error) {
+	var sigs []string
+	for sig != "" {
+		n := 0
+		for depth := 0; ; n++ {
+			if n == len(sig) {
+				return nil, fmt.Errorf("invalid signature %q", sig)
+			}
+			switch sig[n] {
+			case 'a':
+				continue
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+		sigs = append(sigs, sig[:n+1])
+		sig = sig[n+1:]
+	}
+
+	return sigs, nil
+}
+
+// jsonValue converts the given dbus value into a value which can be encoded by encoding/json. Variants are replaced by
+// their values or, if typed is true, encoded as typedJSON.
+func jsonValue(v reflect.Value, typed bool) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch value := v.Interface().(type) {
+	case dbus.Variant:
+		if typed {
+			return map[string]interface{}{"sig": value.Signature().String(), "value": jsonValue(reflect.ValueOf(value.Value()), typed)}
+		}
+		return jsonValue(reflect.ValueOf(value.Value()), typed)
+	case dbus.ObjectPath:
+		return string(value)
+	case dbus.Signature:
+		return value.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = jsonValue(v.Index(i), typed)
+		}
+		return values
+	case reflect.Map:
+		values := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values[fmt.Sprint(iter.Key().Interface())] = jsonValue(iter.Value(), typed)
+		}
+		return values
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return jsonValue(v.Elem(), typed)
+	}
+
+	return v.Interface()
+}
+
+// unmarshalUnknownJSON decodes the given JSON value into a dbus value (see Metadata.UnmarshalJSON).
+func unmarshalUnknownJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v interface{}
+	err := d.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbusValue(v), nil
+}
+
+func dbusValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case []interface{}:
+		stringValues := make([]string, 0, len(value))
+		for _, e := range value {
+			s, ok := e.(string)
+			if !ok {
+				break
+			}
+			stringValues = append(stringValues, s)
+		}
+		if len(stringValues) == len(value) {
+			return stringValues
+		}
+
+		variants := make([]dbus.Variant, 0, len(value))
+		for _, e := range value {
+			if e = dbusValue(e); e != nil {
+				variants = append(variants, dbus.MakeVariant(e))
+			}
+		}
+		return variants
+	case map[string]interface{}:
+		variants := make(map[string]dbus.Variant, len(value))
+		for k, e := range value {
+			if e = dbusValue(e); e != nil {
+				variants[k] = dbus.MakeVariant(e)
+			}
+		}
+		return variants
+	}
+
+	return v
+}
@@ -0,0 +1,188 @@
+package mpris
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata_JSON(t *testing.T) {
+	md := parseMetadata(t, `{
+		'mpris:trackid': <objectpath '/org/mpris/MediaPlayer2/Track/1'>,
+		'mpris:length': <int64 215000000>,
+		'xesam:artist': <['Artist 1', 'Artist 2']>,
+		'xesam:autoRating': <0.5>,
+		'xesam:contentCreated': <'2019'>,
+		'xesam:trackNumber': <int32 3>,
+		'xesam:title': <'Title'>,
+		'xesam:discNumber': <uint64 2>,
+		'vlc:count': <int64 42>,
+		'vlc:small': <int32 7>,
+		'vlc:rating': <1.5>,
+		'vlc:live': <true>,
+		'vlc:path': <objectpath '/path'>,
+		'vlc:signature': <signature 'as'>,
+		'vlc:bytes': <[byte 0x61, 0x62]>,
+		'vlc:tags': <['a', 'b']>,
+		'vlc:mixed': <[<'a'>, <int64 1>]>,
+		'vlc:index': <{int32 1: 'one'}>,
+		'vlc:nested': <{'path': <objectpath '/nested'>, 'variant': <<'v'>>}>
+	}`)
+	md["vlc:pair"] = dbus.MakeVariantWithSignature([]interface{}{"a", uint32(1)}, dbus.ParseSignatureMust("(su)"))
+
+	data, err := json.Marshal(md)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"mpris:trackid": "/org/mpris/MediaPlayer2/Track/1",
+		"mpris:length": 215000000,
+		"xesam:artist": ["Artist 1", "Artist 2"],
+		"xesam:autoRating": 0.5,
+		"xesam:contentCreated": "2019",
+		"xesam:trackNumber": 3,
+		"xesam:title": "Title",
+		"xesam:discNumber": {"sig": "t", "value": 2},
+		"vlc:count": {"sig": "x", "value": 42},
+		"vlc:small": {"sig": "i", "value": 7},
+		"vlc:rating": {"sig": "d", "value": 1.5},
+		"vlc:live": {"sig": "b", "value": true},
+		"vlc:path": {"sig": "o", "value": "/path"},
+		"vlc:signature": {"sig": "g", "value": "as"},
+		"vlc:bytes": {"sig": "ay", "value": [97, 98]},
+		"vlc:tags": {"sig": "as", "value": ["a", "b"]},
+		"vlc:mixed": {"sig": "av", "value": [{"sig": "s", "value": "a"}, {"sig": "x", "value": 1}]},
+		"vlc:pair": {"sig": "(su)", "value": ["a", 1]},
+		"vlc:index": {"sig": "a{is}", "value": {"1": "one"}},
+		"vlc:nested": {"sig": "a{sv}", "value": {
+			"path": {"sig": "o", "value": "/nested"},
+			"variant": {"sig": "v", "value": {"sig": "s", "value": "v"}}
+		}}
+	}`, string(data))
+
+	var decoded Metadata
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, md, decoded)
+}
+
+func TestMetadata_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenJSON        string
+		expectedMetadata Metadata
+		expectedErr      string
+	}{
+		{
+			name:             "null",
+			givenJSON:        `null`,
+			expectedMetadata: nil,
+		}, {
+			name:      "null values",
+			givenJSON: `{"xesam:title": null, "vlc:unknown": null, "mpris:trackid": "/track/1"}`,
+			expectedMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+			},
+		}, {
+			name:      "unknown fields without signature",
+			givenJSON: `{"vlc:count": 42, "vlc:tags": ["a"], "vlc:object": {"sig": "s"}}`,
+			expectedMetadata: Metadata{
+				"vlc:count":  dbus.MakeVariant(int64(42)),
+				"vlc:tags":   dbus.MakeVariant([]string{"a"}),
+				"vlc:object": dbus.MakeVariant(map[string]dbus.Variant{"sig": dbus.MakeVariant("s")}),
+			},
+		}, {
+			name:      "known field with signature",
+			givenJSON: `{"mpris:length": {"sig": "t", "value": 42}}`,
+			expectedMetadata: Metadata{
+				"mpris:length": dbus.MakeVariant(uint64(42)),
+			},
+		}, {
+			name:        "invalid signature",
+			givenJSON:   `{"vlc:count": {"sig": "xx", "value": 42}}`,
+			expectedErr: "failed to decode \"vlc:count\": signature \"xx\" is not a single complete type",
+		}, {
+			name:        "value not matching the signature",
+			givenJSON:   `{"vlc:count": {"sig": "i", "value": "42"}}`,
+			expectedErr: "failed to decode \"vlc:count\": json: cannot unmarshal string into Go value of type int32",
+		}, {
+			name:        "struct with missing fields",
+			givenJSON:   `{"vlc:pair": {"sig": "(su)", "value": ["a"]}}`,
+			expectedErr: "failed to decode \"vlc:pair\": struct \"(su)\" has 1 fields instead of 2",
+		}, {
+			name:        "unsupported signature",
+			givenJSON:   `{"vlc:fd": {"sig": "h", "value": 1}}`,
+			expectedErr: "failed to decode \"vlc:fd\": unsupported signature \"h\"",
+		}, {
+			name:        "invalid type of known field",
+			givenJSON:   `{"xesam:trackNumber": "three"}`,
+			expectedErr: "failed to decode \"xesam:trackNumber\": json: cannot unmarshal string into Go value of type int32",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md Metadata
+			err := json.Unmarshal([]byte(tt.givenJSON), &md)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedMetadata, md)
+		})
+	}
+}
+
+func TestMetadata_UnmarshalJSON_NoObject(t *testing.T) {
+	var md Metadata
+	err := json.Unmarshal([]byte(`["mpris:trackid"]`), &md)
+	assert.Error(t, err)
+	assert.Nil(t, md)
+}
+
+func TestPlayerState_JSON(t *testing.T) {
+	shuffle := true
+	state := PlayerState{
+		PlaybackStatus: PlaybackStatusPlaying,
+		LoopStatus:     LoopStatusTrack,
+		Rate:           1,
+		Shuffle:        &shuffle,
+		Metadata: Metadata{
+			"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+			"xesam:title":   dbus.MakeVariant("Title"),
+		},
+		Volume:      0.5,
+		Position:    90 * time.Second,
+		MinimumRate: 0.5,
+		MaximumRate: 2,
+		CanGoNext:   true,
+		CanPlay:     true,
+		CanPause:    true,
+		CanSeek:     true,
+		CanControl:  true,
+	}
+
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"playbackStatus": "Playing",
+		"loopStatus": "Track",
+		"rate": 1,
+		"shuffle": true,
+		"metadata": {"mpris:trackid": "/track/1", "xesam:title": "Title"},
+		"volume": 0.5,
+		"position": 90000000000,
+		"minimumRate": 0.5,
+		"maximumRate": 2,
+		"canGoNext": true,
+		"canGoPrevious": false,
+		"canPlay": true,
+		"canPause": true,
+		"canSeek": true,
+		"canControl": true
+	}`, string(data))
+
+	var decoded PlayerState
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, state, decoded)
+}
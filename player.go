@@ -3,6 +3,8 @@ package mpris
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 )
@@ -16,7 +18,7 @@ const (
 	playerPlayPauseMethod        = playerInterface + ".PlayPause"
 	playerStopMethod             = playerInterface + ".Stop"
 	playerPlayMethod             = playerInterface + ".Play"
-	playerSeekMethod             = playerInterface + ".SeekTo"
+	playerSeekMethod             = playerInterface + ".Seek"
 	playerSetPositionMethod      = playerInterface + ".SetPosition"
 	playerOpenURIMethod          = playerInterface + ".OpenUri"
 	playerPlaybackStatusProperty = playerInterface + ".PlaybackStatus"
@@ -34,7 +36,7 @@ const (
 	playerCanPauseProperty       = playerInterface + ".CanPause"
 	playerCanSeekProperty        = playerInterface + ".CanSeek"
 	playerCanControlProperty     = playerInterface + ".CanControl"
-	signalNameSeeked             = "org.mpris.MediaPlayer2.Player.Seeked"
+	playerSeekedMember           = "Seeked"
 )
 
 var dbusSessionBus = dbus.SessionBus
@@ -43,15 +45,17 @@ var dbusSessionBus = dbus.SessionBus
 type dbusConn interface {
 	Object(string, dbus.ObjectPath) dbusBusObject
 	AddMatchSignal(...dbus.MatchOption) error
+	RemoveMatchSignal(...dbus.MatchOption) error
 	Signal(ch chan<- *dbus.Signal)
+	RemoveSignal(ch chan<- *dbus.Signal)
 	Close() error
 }
 
 //go:generate moq -out dbus-bus-object_moq_test.go . dbusBusObject
 type dbusBusObject interface {
-	Call(method string, flags dbus.Flags, args ...interface{}) dbusCall
-	GetProperty(p string) (v dbus.Variant, e error)
-	SetProperty(p string, v interface{}) error
+	CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall
+	GetPropertyWithContext(ctx context.Context, p string) (v dbus.Variant, e error)
+	SetPropertyWithContext(ctx context.Context, p string, v interface{}) error
 }
 
 //go:generate moq -out dbus-call_moq_test.go . dbusCall
@@ -60,6 +64,8 @@ type dbusCall interface {
 }
 
 // Player is an implementation of dbus org.mpris.MediaPlayer2.Player. see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html
+// Errors of method calls wrap the dbus.Error returned by the media player or the bus (e.g. when the player is not
+// available anymore: org.freedesktop.DBus.Error.ServiceUnknown). It can be inspected via errors.As.
 // Use NewPlayer to create a new instance with a connected session-bus via dbus.SessionBus.
 // Use NewPlayerWithConnection when you want to use a self-configured dbus.Conn
 type Player struct {
@@ -111,8 +117,13 @@ func (p Player) Close() error {
 // If playback is paused or stopped, it remains that way.
 // If CanGoNext is false, attempting to call this method should have no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Next
-func (p Player) Next() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerNextMethod, 0)
+func (p Player) Next() error {
+	return p.NextContext(context.Background())
+}
+
+// NextContext is like Next but uses the given context for the dbus call.
+func (p Player) NextContext(ctx context.Context) error {
+	return p.call(ctx, playerNextMethod)
 }
 
 // Previous skips to the previous track in the tracklist.
@@ -120,8 +131,13 @@ func (p Player) Next() {
 // If playback is paused or stopped, it remains that way.
 // If CanGoPrevious is false, attempting to call this method should have no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Previous
-func (p Player) Previous() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerPreviousMethod, 0)
+func (p Player) Previous() error {
+	return p.PreviousContext(context.Background())
+}
+
+// PreviousContext is like Previous but uses the given context for the dbus call.
+func (p Player) PreviousContext(ctx context.Context) error {
+	return p.call(ctx, playerPreviousMethod)
 }
 
 // Pause pauses playback.
@@ -129,8 +145,13 @@ func (p Player) Previous() {
 // Calling Play after this should cause playback to start again from the same position.
 // If CanPause is false, attempting to call this method should have no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Pause
-func (p Player) Pause() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerPauseMethod, 0)
+func (p Player) Pause() error {
+	return p.PauseContext(context.Background())
+}
+
+// PauseContext is like Pause but uses the given context for the dbus call.
+func (p Player) PauseContext(ctx context.Context) error {
+	return p.call(ctx, playerPauseMethod)
 }
 
 // Play starts or resumes playback.
@@ -139,8 +160,13 @@ func (p Player) Pause() {
 // If there is no track to play, this has no effect.
 // If CanPlay is false, attempting to call this method should have no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Play
-func (p Player) Play() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerPlayMethod, 0)
+func (p Player) Play() error {
+	return p.PlayContext(context.Background())
+}
+
+// PlayContext is like Play but uses the given context for the dbus call.
+func (p Player) PlayContext(ctx context.Context) error {
+	return p.call(ctx, playerPlayMethod)
 }
 
 // PlayPause pauses playback.
@@ -148,8 +174,13 @@ func (p Player) Play() {
 // If playback is stopped, starts playback.
 // If CanPause is false, attempting to call this method should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:PlayPause
-func (p Player) PlayPause() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerPlayPauseMethod, 0)
+func (p Player) PlayPause() error {
+	return p.PlayPauseContext(context.Background())
+}
+
+// PlayPauseContext is like PlayPause but uses the given context for the dbus call.
+func (p Player) PlayPauseContext(ctx context.Context) error {
+	return p.call(ctx, playerPlayPauseMethod)
 }
 
 // Stop stops playback.
@@ -157,8 +188,13 @@ func (p Player) PlayPause() {
 // Calling Play after this should cause playback to start again from the beginning of the track.
 // If CanControl is false, attempting to call this method should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Stop
-func (p Player) Stop() {
-	p.connection.Object(p.name, playerObjectPath).Call(playerStopMethod, 0)
+func (p Player) Stop() error {
+	return p.StopContext(context.Background())
+}
+
+// StopContext is like Stop but uses the given context for the dbus call.
+func (p Player) StopContext(ctx context.Context) error {
+	return p.call(ctx, playerStopMethod)
 }
 
 // SeekTo seeks forward in the current track by the specified number of microseconds.
@@ -168,8 +204,44 @@ func (p Player) Stop() {
 // If the value passed in would mean seeking beyond the end of the track, acts like a call to Next.
 // If the CanSeek property is false, this has no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:Seek
-func (p Player) SeekTo(offset int64) {
-	p.connection.Object(p.name, playerObjectPath).Call(playerSeekMethod, 0, offset)
+func (p Player) SeekTo(offset int64) error {
+	return p.SeekToContext(context.Background(), offset)
+}
+
+// SeekToContext is like SeekTo but uses the given context for the dbus call.
+func (p Player) SeekToContext(ctx context.Context, offset int64) error {
+	return p.call(ctx, playerSeekMethod, offset)
+}
+
+// Seek seeks forward in the current track by the given offset. A negative offset seeks back.
+// In contrast to SeekTo, the request is validated before: ErrSeekNotSupported is returned when the CanSeek property is
+// false and ErrPositionOutOfRange when the resulting position would be beyond the end of the track (mpris:length).
+// Seeking back further than the start of the track seeks to the start of the track.
+func (p Player) Seek(offset time.Duration) error {
+	return p.SeekContext(context.Background(), offset)
+}
+
+// SeekContext is like Seek but uses the given context for the dbus calls.
+func (p Player) SeekContext(ctx context.Context, offset time.Duration) error {
+	length, err := p.seekableLength(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	current, err := p.PositionDurationContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	target := current + offset
+	if target < 0 {
+		offset = -current
+	}
+	if length > 0 && target > length {
+		return fmt.Errorf("position %s is beyond the end of the track (%s): %w", target, length, ErrPositionOutOfRange)
+	}
+
+	return p.SeekToContext(ctx, offset.Microseconds())
 }
 
 // OpenURI opens the Uri given as an argument
@@ -180,19 +252,30 @@ func (p Player) SeekTo(offset int64) {
 // Clients should not assume that the Uri has been opened as soon as this method returns. They should wait until the mpris:trackid field in the Metadata property changes.
 // If the media player implements the TrackList interface, then the opened track should be made part of the tracklist, the org.mpris.MediaPlayer2.TrackList.TrackAdded or org.mpris.MediaPlayer2.TrackList.TrackListReplaced signal should be fired, as well as the org.freedesktop.DBus.Properties.PropertiesChanged signal on the tracklist interface.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:OpenUri
-func (p Player) OpenURI(uri string) {
-	p.connection.Object(p.name, playerObjectPath).Call(playerOpenURIMethod, 0, uri)
+func (p Player) OpenURI(uri string) error {
+	return p.OpenURIContext(context.Background(), uri)
+}
+
+// OpenURIContext is like OpenURI but uses the given context for the dbus call.
+func (p Player) OpenURIContext(ctx context.Context, uri string) error {
+	return p.call(ctx, playerOpenURIMethod, uri)
 }
 
 // PlaybackStatus returns the current playback status.
 // May be "Playing" as PlaybackStatusPlaying, "Paused" as PlaybackStatusPaused or "Stopped" as PlaybackStatusStopped.
 // https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:PlaybackStatus
 func (p Player) PlaybackStatus() (PlaybackStatus, error) {
-	v, err := p.getProperty(playerPlaybackStatusProperty)
+	return p.PlaybackStatusContext(context.Background())
+}
+
+// PlaybackStatusContext is like PlaybackStatus but uses the given context for the dbus call.
+func (p Player) PlaybackStatusContext(ctx context.Context) (PlaybackStatus, error) {
+	v, err := p.getProperty(ctx, playerPlaybackStatusProperty)
 	if err != nil {
 		return "", err
 	}
-	return PlaybackStatus(v.Value().(string)), nil
+	s, err := decodeValue[string](playerPlaybackStatusProperty, v)
+	return PlaybackStatus(s), err
 }
 
 // LoopStatus returns the current loop / repeat status
@@ -203,11 +286,17 @@ func (p Player) PlaybackStatus() (PlaybackStatus, error) {
 // If CanControl is false, attempting to set this property (SetLoopStatus) should have no effect and raise an error.
 // https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:LoopStatus
 func (p Player) LoopStatus() (LoopStatus, error) {
-	v, err := p.getProperty(playerLoopStatusProperty)
+	return p.LoopStatusContext(context.Background())
+}
+
+// LoopStatusContext is like LoopStatus but uses the given context for the dbus call.
+func (p Player) LoopStatusContext(ctx context.Context) (LoopStatus, error) {
+	v, err := p.getProperty(ctx, playerLoopStatusProperty)
 	if err != nil {
 		return "", err
 	}
-	return LoopStatus(v.Value().(string)), nil
+	s, err := decodeValue[string](playerLoopStatusProperty, v)
+	return LoopStatus(s), err
 }
 
 // SetLoopStatus sets the current loop / repeat status
@@ -218,7 +307,12 @@ func (p Player) LoopStatus() (LoopStatus, error) {
 // If CanControl is false, attempting to set this property (SetLoopStatus) should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:LoopStatus
 func (p Player) SetLoopStatus(status LoopStatus) error {
-	return p.setProperty(playerLoopStatusProperty, string(status))
+	return p.SetLoopStatusContext(context.Background(), status)
+}
+
+// SetLoopStatusContext is like SetLoopStatus but uses the given context for the dbus call.
+func (p Player) SetLoopStatusContext(ctx context.Context, status LoopStatus) error {
+	return p.setProperty(ctx, playerLoopStatusProperty, string(status))
 }
 
 // Rate return the current playback rate.
@@ -227,11 +321,16 @@ func (p Player) SetLoopStatus(status LoopStatus) error {
 // Not all values may be accepted by the media player. It is left to media player implementations to decide how to deal with values they cannot use; they may either ignore them or pick a "best fit" value. Clients are recommended to only use sensible fractions or multiples of 1 (eg: 0.5, 0.25, 1.5, 2.0, etc).
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Rate
 func (p Player) Rate() (float64, error) {
-	v, err := p.getProperty(playerRateProperty)
+	return p.RateContext(context.Background())
+}
+
+// RateContext is like Rate but uses the given context for the dbus call.
+func (p Player) RateContext(ctx context.Context) (float64, error) {
+	v, err := p.getProperty(ctx, playerRateProperty)
 	if err != nil {
 		return 0, err
 	}
-	return v.Value().(float64), nil
+	return decodeFloat64(playerRateProperty, v)
 }
 
 // SetRate sets the current playback rate.
@@ -240,25 +339,40 @@ func (p Player) Rate() (float64, error) {
 // Not all values may be accepted by the media player. It is left to media player implementations to decide how to deal with values they cannot use; they may either ignore them or pick a "best fit" value. Clients are recommended to only use sensible fractions or multiples of 1 (eg: 0.5, 0.25, 1.5, 2.0, etc).
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Rate
 func (p Player) SetRate(rate float64) error {
-	return p.setProperty(playerRateProperty, rate)
+	return p.SetRateContext(context.Background(), rate)
+}
+
+// SetRateContext is like SetRate but uses the given context for the dbus call.
+func (p Player) SetRateContext(ctx context.Context, rate float64) error {
+	return p.setProperty(ctx, playerRateProperty, rate)
 }
 
 // Shuffle returns a value of false indicates that playback is progressing linearly through a playlist, while true means playback is progressing through a playlist in some other order.
 // If CanControl is false, attempting to set this property should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Shuffle
 func (p Player) Shuffle() (bool, error) {
-	v, err := p.getProperty(playerShuffleProperty)
+	return p.ShuffleContext(context.Background())
+}
+
+// ShuffleContext is like Shuffle but uses the given context for the dbus call.
+func (p Player) ShuffleContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerShuffleProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerShuffleProperty, v)
 }
 
 // SetShuffle set a value of false indicates that playback is progressing linearly through a playlist, while true means playback is progressing through a playlist in some other order.
 // If CanControl is false, attempting to set this property should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Shuffle
 func (p Player) SetShuffle(shuffle bool) error {
-	return p.setProperty(playerShuffleProperty, shuffle)
+	return p.SetShuffleContext(context.Background(), shuffle)
+}
+
+// SetShuffleContext is like SetShuffle but uses the given context for the dbus call.
+func (p Player) SetShuffleContext(ctx context.Context, shuffle bool) error {
+	return p.setProperty(ctx, playerShuffleProperty, shuffle)
 }
 
 // Metadata of the current element.
@@ -266,11 +380,16 @@ func (p Player) SetShuffle(shuffle bool) error {
 // See the type documentation for more details.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Metadata
 func (p Player) Metadata() (Metadata, error) {
-	v, err := p.getProperty(playerMetadataProperty)
+	return p.MetadataContext(context.Background())
+}
+
+// MetadataContext is like Metadata but uses the given context for the dbus call.
+func (p Player) MetadataContext(ctx context.Context) (Metadata, error) {
+	v, err := p.getProperty(ctx, playerMetadataProperty)
 	if err != nil {
 		return nil, err
 	}
-	return v.Value().(map[string]dbus.Variant), nil
+	return decodeValue[map[string]dbus.Variant](playerMetadataProperty, v)
 }
 
 // Volume returns the volume level.
@@ -278,11 +397,16 @@ func (p Player) Metadata() (Metadata, error) {
 // If CanControl is false, attempting to set this property should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Volume
 func (p Player) Volume() (float64, error) {
-	v, err := p.getProperty(playerVolumeProperty)
+	return p.VolumeContext(context.Background())
+}
+
+// VolumeContext is like Volume but uses the given context for the dbus call.
+func (p Player) VolumeContext(ctx context.Context) (float64, error) {
+	v, err := p.getProperty(ctx, playerVolumeProperty)
 	if err != nil {
 		return 0, err
 	}
-	return v.Value().(float64), nil
+	return decodeFloat64(playerVolumeProperty, v)
 }
 
 // SetVolume sets the volume level.
@@ -290,7 +414,12 @@ func (p Player) Volume() (float64, error) {
 // If CanControl is false, attempting to set this property should have no effect and raise an error.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Volume
 func (p Player) SetVolume(volume float64) error {
-	return p.setProperty(playerVolumeProperty, volume)
+	return p.SetVolumeContext(context.Background(), volume)
+}
+
+// SetVolumeContext is like SetVolume but uses the given context for the dbus call.
+func (p Player) SetVolumeContext(ctx context.Context, volume float64) error {
+	return p.setProperty(ctx, playerVolumeProperty, volume)
 }
 
 // Position returns current track position in microseconds, between 0 and the 'mpris:length' metadata entry (see Metadata).
@@ -298,11 +427,30 @@ func (p Player) SetVolume(volume float64) error {
 // If the playback progresses in a way that is inconsistent with the Rate property, the Seeked signal is emitted.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Position
 func (p Player) Position() (int64, error) {
-	v, err := p.getProperty(playerPositionProperty)
+	return p.PositionContext(context.Background())
+}
+
+// PositionContext is like Position but uses the given context for the dbus call.
+func (p Player) PositionContext(ctx context.Context) (int64, error) {
+	v, err := p.getProperty(ctx, playerPositionProperty)
+	if err != nil {
+		return 0, err
+	}
+	return decodeInt64(playerPositionProperty, v)
+}
+
+// PositionDuration is like Position but returns the position as time.Duration.
+func (p Player) PositionDuration() (time.Duration, error) {
+	return p.PositionDurationContext(context.Background())
+}
+
+// PositionDurationContext is like PositionDuration but uses the given context for the dbus call.
+func (p Player) PositionDurationContext(ctx context.Context) (time.Duration, error) {
+	position, err := p.PositionContext(ctx)
 	if err != nil {
 		return 0, err
 	}
-	return v.Value().(int64), nil
+	return microseconds(position), nil
 }
 
 // SetPosition Sets the current track position in microseconds.
@@ -314,8 +462,38 @@ func (p Player) Position() (int64, error) {
 // If the Position argument is greater than the track length, do nothing.
 // If the CanSeek property is false, this has no effect.
 // see: https://specifications.freedesktop.org/mpris-spec/latest/Player_Interface.html#Method:SetPosition
-func (p Player) SetPosition(trackID dbus.ObjectPath, position int64) {
-	p.connection.Object(p.name, playerObjectPath).Call(playerSetPositionMethod, 0, trackID, position)
+func (p Player) SetPosition(trackID dbus.ObjectPath, position int64) error {
+	return p.SetPositionContext(context.Background(), trackID, position)
+}
+
+// SetPositionContext is like SetPosition but uses the given context for the dbus call.
+func (p Player) SetPositionContext(ctx context.Context, trackID dbus.ObjectPath, position int64) error {
+	return p.call(ctx, playerSetPositionMethod, trackID, position)
+}
+
+// SetPositionDuration sets the position of the track with the given trackID to the given position.
+// In contrast to SetPosition, the request is validated before: ErrSeekNotSupported is returned when the CanSeek
+// property is false and ErrPositionOutOfRange when the position is beyond the end of the current track (mpris:length).
+// A negative position sets the position to the start of the track.
+func (p Player) SetPositionDuration(trackID dbus.ObjectPath, position time.Duration) error {
+	return p.SetPositionDurationContext(context.Background(), trackID, position)
+}
+
+// SetPositionDurationContext is like SetPositionDuration but uses the given context for the dbus calls.
+func (p Player) SetPositionDurationContext(ctx context.Context, trackID dbus.ObjectPath, position time.Duration) error {
+	length, err := p.seekableLength(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	if position < 0 {
+		position = 0
+	}
+	if length > 0 && position > length {
+		return fmt.Errorf("position %s is beyond the end of the track (%s): %w", position, length, ErrPositionOutOfRange)
+	}
+
+	return p.SetPositionContext(ctx, trackID, position.Microseconds())
 }
 
 // MinimumRate returns the minimum value which the Rate property can take. Clients should not attempt to set the Rate property below this value.
@@ -323,22 +501,32 @@ func (p Player) SetPosition(trackID dbus.ObjectPath, position int64) {
 // This value should always be 1.0 or less.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:MinimumRate
 func (p Player) MinimumRate() (float64, error) {
-	v, err := p.getProperty(playerMinimumRateProperty)
+	return p.MinimumRateContext(context.Background())
+}
+
+// MinimumRateContext is like MinimumRate but uses the given context for the dbus call.
+func (p Player) MinimumRateContext(ctx context.Context) (float64, error) {
+	v, err := p.getProperty(ctx, playerMinimumRateProperty)
 	if err != nil {
 		return 0, err
 	}
-	return v.Value().(float64), nil
+	return decodeFloat64(playerMinimumRateProperty, v)
 }
 
 // MaximumRate returns the maximum value which the Rate property can take. Clients should not attempt to set the Rate property above this value.
 // This value should always be 1.0 or greater.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:MaximumRate
 func (p Player) MaximumRate() (float64, error) {
-	v, err := p.getProperty(playerMaximumRateProperty)
+	return p.MaximumRateContext(context.Background())
+}
+
+// MaximumRateContext is like MaximumRate but uses the given context for the dbus call.
+func (p Player) MaximumRateContext(ctx context.Context) (float64, error) {
+	v, err := p.getProperty(ctx, playerMaximumRateProperty)
 	if err != nil {
 		return 0, err
 	}
-	return v.Value().(float64), nil
+	return decodeFloat64(playerMaximumRateProperty, v)
 }
 
 // CanGoNext returns true whether the client can call the Next method on this interface and expect the current track to change.
@@ -346,11 +534,16 @@ func (p Player) MaximumRate() (float64, error) {
 // If CanControl is false, this property should also be false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanGoNext
 func (p Player) CanGoNext() (bool, error) {
-	v, err := p.getProperty(playerCanGoNextProperty)
+	return p.CanGoNextContext(context.Background())
+}
+
+// CanGoNextContext is like CanGoNext but uses the given context for the dbus call.
+func (p Player) CanGoNextContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanGoNextProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanGoNextProperty, v)
 }
 
 // CanGoPrevious returns true whether the client can call the Previous method on this interface and expect the current track to change.
@@ -358,11 +551,16 @@ func (p Player) CanGoNext() (bool, error) {
 // If CanControl is false, this property should also be false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanGoPrevious
 func (p Player) CanGoPrevious() (bool, error) {
-	v, err := p.getProperty(playerCanGoPreviousProperty)
+	return p.CanGoPreviousContext(context.Background())
+}
+
+// CanGoPreviousContext is like CanGoPrevious but uses the given context for the dbus call.
+func (p Player) CanGoPreviousContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanGoPreviousProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanGoPreviousProperty, v)
 }
 
 // CanPlay returns true whether playback can be started using Play or PlayPause.
@@ -370,11 +568,16 @@ func (p Player) CanGoPrevious() (bool, error) {
 // If CanControl is false, this property should also be false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanPlay
 func (p Player) CanPlay() (bool, error) {
-	v, err := p.getProperty(playerCanPlayProperty)
+	return p.CanPlayContext(context.Background())
+}
+
+// CanPlayContext is like CanPlay but uses the given context for the dbus call.
+func (p Player) CanPlayContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanPlayProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanPlayProperty, v)
 }
 
 // CanPause returns true whether playback can be paused using Pause or PlayPause.
@@ -382,22 +585,32 @@ func (p Player) CanPlay() (bool, error) {
 // If CanControl is false, this property should also be false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanPause
 func (p Player) CanPause() (bool, error) {
-	v, err := p.getProperty(playerCanPauseProperty)
+	return p.CanPauseContext(context.Background())
+}
+
+// CanPauseContext is like CanPause but uses the given context for the dbus call.
+func (p Player) CanPauseContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanPauseProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanPauseProperty, v)
 }
 
 // CanSeek returns true whether the client can control the playback position using Seek and SetPosition. This may be different for different tracks.
 // If CanControl is false, this property should also be false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanSeek
 func (p Player) CanSeek() (bool, error) {
-	v, err := p.getProperty(playerCanSeekProperty)
+	return p.CanSeekContext(context.Background())
+}
+
+// CanSeekContext is like CanSeek but uses the given context for the dbus call.
+func (p Player) CanSeekContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanSeekProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanSeekProperty, v)
 }
 
 // CanControl is true whether the media player may be controlled over this interface.
@@ -405,11 +618,16 @@ func (p Player) CanSeek() (bool, error) {
 // If this is false, clients should assume that all properties on this interface are read-only (and will raise errors if writing to them is attempted), no methods are implemented and all other properties starting with "Can" are also false.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:CanControl
 func (p Player) CanControl() (bool, error) {
-	v, err := p.getProperty(playerCanControlProperty)
+	return p.CanControlContext(context.Background())
+}
+
+// CanControlContext is like CanControl but uses the given context for the dbus call.
+func (p Player) CanControlContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, playerCanControlProperty)
 	if err != nil {
 		return false, err
 	}
-	return v.Value().(bool), nil
+	return decodeValue[bool](playerCanControlProperty, v)
 }
 
 // Seeked indicates that the track position has changed in a way that is inconsistent with the current playing state.
@@ -419,49 +637,122 @@ func (p Player) CanControl() (bool, error) {
 // This signal does not need to be emitted when playback starts or when the track changes, unless the track is starting
 // at an unexpected position. An expected position would be the last known one when going from Paused to Playing, and 0
 // when going from Stopped to Playing.
+// The new positions in microseconds are emitted until the given context is done. The returned channel will be closed
+// afterwards.
 // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Signal:Seeked
-func (p Player) Seeked(ctx context.Context) (<-chan int, error) {
-	err := p.connection.AddMatchSignal(dbus.WithMatchSender("org.mpris.MediaPlayer2.vlc"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to add signal match option: %w", err)
-	}
-
-	positions := make(chan int)
-	errs := make(chan error)
-	go func() {
-		defer func() {
-			close(positions)
-			close(errs)
-		}()
-
-		signals := make(chan *dbus.Signal)
-		defer close(signals)
-		p.connection.Signal(signals)
-
-	collectPositions:
-		for {
-			select {
-			case sig := <-signals:
-				if sig.Name != signalNameSeeked || // irrelevant signal
-					len(sig.Body) != 1 { // invalid event
-					continue
-				}
-				micros, ok := sig.Body[0].(int64)
-				if !ok { // broken signal
-					continue
-				}
-				positions <- int(micros)
-			case <-ctx.Done():
-				break collectPositions
-			}
+func (p Player) Seeked(ctx context.Context) (<-chan int64, error) {
+	return seeked(ctx, p, func(micros int64) int64 {
+		return micros
+	})
+}
+
+// SeekedDuration is like Seeked but emits the new positions as time.Duration.
+func (p Player) SeekedDuration(ctx context.Context) (<-chan time.Duration, error) {
+	return seeked(ctx, p, microseconds)
+}
+
+func seeked[T any](ctx context.Context, p Player, convert func(micros int64) T) (<-chan T, error) {
+	positions := make(chan T)
+	err := p.subscribe(ctx, playerInterface, playerSeekedMember, func(sig *dbus.Signal) {
+		if len(sig.Body) != 1 { // invalid event
+			return
 		}
-	}()
+		micros, ok := sig.Body[0].(int64)
+		if !ok { // broken signal
+			return
+		}
+
+		select {
+		case positions <- convert(micros):
+		case <-ctx.Done():
+		}
+	}, func() {
+		close(positions)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return positions, nil
 }
 
-func (p Player) getProperty(property string) (dbus.Variant, error) {
-	v, err := p.connection.Object(p.name, playerObjectPath).GetProperty(property)
+func (p Player) call(ctx context.Context, method string, args ...interface{}) error {
+	return p.callAndStore(ctx, method, args)
+}
+
+func (p Player) callAndStore(ctx context.Context, method string, args []interface{}, retvalues ...interface{}) error {
+	err := p.connection.Object(p.name, playerObjectPath).CallWithContext(ctx, method, 0, args...).Store(retvalues...)
+	if err != nil {
+		if name, ok := dbusErrorName(err); ok && name != err.Error() {
+			return fmt.Errorf("failed to call method %q: %s: %w", method, name, err)
+		}
+		return fmt.Errorf("failed to call method %q: %w", method, err)
+	}
+
+	return nil
+}
+
+// subscribe calls handle for every signal iface.member emitted by the player until ctx is done. When member is empty,
+// all signals of iface are handled. Afterwards done is called.
+// Signals are filtered by the unique name which owns the players name at subscription time.
+func (p Player) subscribe(ctx context.Context, iface, member string, handle func(sig *dbus.Signal), done func()) error {
+	sender, err := nameOwner(ctx, p.connection, p.name)
+	if err != nil {
+		return err
+	}
+
+	options := []dbus.MatchOption{
+		dbus.WithMatchSender(p.name),
+		dbus.WithMatchObjectPath(playerObjectPath),
+		dbus.WithMatchInterface(iface),
+	}
+	if member != "" {
+		options = append(options, dbus.WithMatchMember(member))
+	}
+
+	return subscribe(ctx, p.connection, options, func(sig *dbus.Signal) {
+		if sig.Sender != sender || sig.Path != playerObjectPath || // signal of another object
+			!strings.HasPrefix(sig.Name, iface+".") || // irrelevant interface
+			member != "" && sig.Name != iface+"."+member { // irrelevant member
+			return
+		}
+		handle(sig)
+	}, done)
+}
+
+// seekableLength returns ErrSeekNotSupported when the player can not seek. Otherwise, it returns the length of the
+// current track or 0 when it is unknown. When trackID is given but does not match the current track, 0 is returned.
+func (p Player) seekableLength(ctx context.Context, trackID dbus.ObjectPath) (time.Duration, error) {
+	canSeek, err := p.CanSeekContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !canSeek {
+		return 0, ErrSeekNotSupported
+	}
+
+	md, err := p.MetadataContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if trackID != "" {
+		currentTrackID, err := md.MPRISTrackID()
+		if err != nil || currentTrackID != trackID {
+			return 0, nil
+		}
+	}
+
+	length, err := md.Length()
+	if err != nil {
+		return 0, nil
+	}
+
+	return length, nil
+}
+
+func (p Player) getProperty(ctx context.Context, property string) (dbus.Variant, error) {
+	v, err := p.connection.Object(p.name, playerObjectPath).GetPropertyWithContext(ctx, property)
 	if err != nil {
 		return dbus.Variant{}, fmt.Errorf("failed to get property %q: %w", property, err)
 	}
@@ -469,8 +760,8 @@ func (p Player) getProperty(property string) (dbus.Variant, error) {
 	return v, nil
 }
 
-func (p Player) setProperty(property string, value interface{}) error {
-	err := p.connection.Object(p.name, playerObjectPath).SetProperty(property, dbus.MakeVariant(value))
+func (p Player) setProperty(ctx context.Context, property string, value interface{}) error {
+	err := p.connection.Object(p.name, playerObjectPath).SetPropertyWithContext(ctx, property, dbus.MakeVariant(value))
 	if err != nil {
 		return fmt.Errorf("failed to set property %q: %w", property, err)
 	}
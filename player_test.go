@@ -16,18 +16,20 @@ func TestPlayer_Methods(t *testing.T) {
 	tests := []struct {
 		name           string
 		givenName      string
-		action         func(p *Player)
+		storeErr       error
+		action         func(p *Player) error
 		expectedDest   string
 		expectedPath   dbus.ObjectPath
 		expectedMethod string
 		expectedFlags  dbus.Flags
 		expectedArgs   []interface{}
+		expectedErr    string
 	}{
 		{
 			name:      "Next",
 			givenName: "next",
-			action: func(p *Player) {
-				p.Next()
+			action: func(p *Player) error {
+				return p.Next()
 			},
 			expectedDest:   "next",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -38,8 +40,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "Previous",
 			givenName: "previous",
-			action: func(p *Player) {
-				p.Previous()
+			action: func(p *Player) error {
+				return p.Previous()
 			},
 			expectedDest:   "previous",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -50,8 +52,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "Pause",
 			givenName: "pause",
-			action: func(p *Player) {
-				p.Pause()
+			action: func(p *Player) error {
+				return p.Pause()
 			},
 			expectedDest:   "pause",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -62,8 +64,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "Play",
 			givenName: "play",
-			action: func(p *Player) {
-				p.Play()
+			action: func(p *Player) error {
+				return p.Play()
 			},
 			expectedDest:   "play",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -74,8 +76,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "PlayPause",
 			givenName: "play-pause",
-			action: func(p *Player) {
-				p.PlayPause()
+			action: func(p *Player) error {
+				return p.PlayPause()
 			},
 			expectedDest:   "play-pause",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -86,20 +88,20 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "SeekTo",
 			givenName: "seek-to",
-			action: func(p *Player) {
-				p.SeekTo(12356789)
+			action: func(p *Player) error {
+				return p.SeekTo(12356789)
 			},
 			expectedDest:   "seek-to",
 			expectedPath:   "/org/mpris/MediaPlayer2",
-			expectedMethod: "org.mpris.MediaPlayer2.Player.SeekTo",
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Seek",
 			expectedFlags:  0,
 			expectedArgs:   []interface{}{int64(12356789)},
 		},
 		{
 			name:      "Stop",
 			givenName: "stop",
-			action: func(p *Player) {
-				p.Stop()
+			action: func(p *Player) error {
+				return p.Stop()
 			},
 			expectedDest:   "stop",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -110,8 +112,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "SetPosition",
 			givenName: "set-position",
-			action: func(p *Player) {
-				p.SetPosition("/my/path", 123456789)
+			action: func(p *Player) error {
+				return p.SetPosition("/my/path", 123456789)
 			},
 			expectedDest:   "set-position",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -122,8 +124,8 @@ func TestPlayer_Methods(t *testing.T) {
 		{
 			name:      "OpenUri",
 			givenName: "open-uri",
-			action: func(p *Player) {
-				p.OpenURI("file://my/uri")
+			action: func(p *Player) error {
+				return p.OpenURI("file://my/uri")
 			},
 			expectedDest:   "open-uri",
 			expectedPath:   "/org/mpris/MediaPlayer2",
@@ -131,6 +133,51 @@ func TestPlayer_Methods(t *testing.T) {
 			expectedFlags:  0,
 			expectedArgs:   []interface{}{"file://my/uri"},
 		},
+		{
+			name:      "Next error",
+			givenName: "next",
+			storeErr:  errors.New("nope"),
+			action: func(p *Player) error {
+				return p.Next()
+			},
+			expectedDest:   "next",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Next",
+			expectedFlags:  0,
+			expectedArgs:   nil,
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.Player.Next\": nope",
+		},
+		{
+			name:      "Play dbus error",
+			givenName: "play",
+			storeErr: dbus.Error{
+				Name: "org.freedesktop.DBus.Error.ServiceUnknown",
+				Body: []interface{}{"The name play was not provided by any .service files"},
+			},
+			action: func(p *Player) error {
+				return p.Play()
+			},
+			expectedDest:   "play",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Play",
+			expectedFlags:  0,
+			expectedArgs:   nil,
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.Player.Play\": org.freedesktop.DBus.Error.ServiceUnknown: The name play was not provided by any .service files",
+		},
+		{
+			name:      "OpenUri dbus error without message",
+			givenName: "open-uri",
+			storeErr:  dbus.Error{Name: "org.freedesktop.DBus.Error.InvalidArgs"},
+			action: func(p *Player) error {
+				return p.OpenURI("nope")
+			},
+			expectedDest:   "open-uri",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Player.OpenUri",
+			expectedFlags:  0,
+			expectedArgs:   []interface{}{"nope"},
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.Player.OpenUri\": org.freedesktop.DBus.Error.InvalidArgs",
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,24 +188,29 @@ func TestPlayer_Methods(t *testing.T) {
 			var givenFlags dbus.Flags
 			var givenArgs []interface{}
 
-			tt.action(&Player{
+			err := tt.action(&Player{
 				name: tt.givenName,
 				connection: &dbusConnMock{
 					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
 						givenDest = dest
 						givenPath = path
 						return &dbusBusObjectMock{
-							CallFunc: func(method string, flags dbus.Flags, args ...interface{}) dbusCall {
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
 								givenMethod = method
 								givenFlags = flags
 								givenArgs = args
-								return nil
+								return &dbusCallMock{
+									StoreFunc: func(_ ...interface{}) error {
+										return tt.storeErr
+									},
+								}
 							},
 						}
 					},
 				},
 			})
 
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
 			assert.Equal(t, tt.expectedDest, givenDest, "given dest is not as expected")
 			assert.Equal(t, tt.expectedPath, givenPath, "given path is not as expected")
 			assert.Equal(t, tt.expectedMethod, givenMethod, "given method is not as expected")
@@ -192,6 +244,56 @@ func TestPlayer_GetProperties(t *testing.T) {
 			expectedPath: "/org/mpris/MediaPlayer2",
 			expectedKey:  "org.mpris.MediaPlayer2.Player.PlaybackStatus",
 		},
+		{
+			name:        "PlaybackStatus invalid type",
+			callVariant: dbus.MakeVariant(int32(1)),
+			givenName:   "playback-status",
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.PlaybackStatus()
+				assert.EqualError(t, err, "property \"org.mpris.MediaPlayer2.Player.PlaybackStatus\" of type \"i\" could not be parsed to string: the given type is not as expected")
+				assert.ErrorIs(t, err, ErrTypeNotParsable)
+			},
+			expectedDest: "playback-status",
+			expectedPath: "/org/mpris/MediaPlayer2",
+			expectedKey:  "org.mpris.MediaPlayer2.Player.PlaybackStatus",
+		},
+		{
+			name:      "CanPlay empty variant",
+			givenName: "can-play",
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.CanPlay()
+				assert.EqualError(t, err, "property \"org.mpris.MediaPlayer2.Player.CanPlay\" of type \"\" could not be parsed to bool: the given type is not as expected")
+			},
+			expectedDest: "can-play",
+			expectedPath: "/org/mpris/MediaPlayer2",
+			expectedKey:  "org.mpris.MediaPlayer2.Player.CanPlay",
+		},
+		{
+			name:        "Volume int32",
+			callVariant: dbus.MakeVariant(int32(1)),
+			givenName:   "volume",
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.Volume()
+				assert.NoError(t, err)
+				assert.Equal(t, 1.0, s, "volume is not as expected")
+			},
+			expectedDest: "volume",
+			expectedPath: "/org/mpris/MediaPlayer2",
+			expectedKey:  "org.mpris.MediaPlayer2.Player.Volume",
+		},
+		{
+			name:        "Position uint32",
+			callVariant: dbus.MakeVariant(uint32(220342)),
+			givenName:   "position",
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.Position()
+				assert.NoError(t, err)
+				assert.Equal(t, int64(220342), s, "position is not as expected")
+			},
+			expectedDest: "position",
+			expectedPath: "/org/mpris/MediaPlayer2",
+			expectedKey:  "org.mpris.MediaPlayer2.Player.Position",
+		},
 		{
 			name:      "PlaybackStatus error",
 			callError: errors.New("nope"),
@@ -348,6 +450,19 @@ func TestPlayer_GetProperties(t *testing.T) {
 			expectedPath: "/org/mpris/MediaPlayer2",
 			expectedKey:  "org.mpris.MediaPlayer2.Player.Position",
 		},
+		{
+			name:        "PositionDuration",
+			callVariant: dbus.MakeVariant(int64(220342)),
+			givenName:   "position",
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.PositionDuration()
+				assert.NoError(t, err)
+				assert.Equal(t, 220342*time.Microsecond, s, "position is not as expected")
+			},
+			expectedDest: "position",
+			expectedPath: "/org/mpris/MediaPlayer2",
+			expectedKey:  "org.mpris.MediaPlayer2.Player.Position",
+		},
 		{
 			name:      "Position error",
 			callError: errors.New("nope"),
@@ -573,7 +688,7 @@ func TestPlayer_GetProperties(t *testing.T) {
 					calledDest = dest
 					calledPath = path
 					return &dbusBusObjectMock{
-						GetPropertyFunc: func(key string) (dbus.Variant, error) {
+						GetPropertyWithContextFunc: func(_ context.Context, key string) (dbus.Variant, error) {
 							calledKey = key
 							return tt.callVariant, tt.callError
 						},
@@ -715,7 +830,7 @@ func TestPlayer_SetProperties(t *testing.T) {
 					calledDest = dest
 					calledPath = path
 					return &dbusBusObjectMock{
-						SetPropertyFunc: func(p string, v interface{}) error {
+						SetPropertyWithContextFunc: func(_ context.Context, p string, v interface{}) error {
 							calledProperty = p
 							calledValue = v
 
@@ -735,6 +850,185 @@ func TestPlayer_SetProperties(t *testing.T) {
 	}
 }
 
+func TestPlayer_Seek(t *testing.T) {
+	tests := []struct {
+		name string
+
+		action func(p *Player) error
+
+		givenCanSeek  bool
+		givenPosition int64
+		givenMetadata Metadata
+		getPropErr    error
+
+		expectedMethod string
+		expectedArgs   []interface{}
+		expectedErr    string
+		expectedErrIs  error
+	}{
+		{
+			name: "Seek forward",
+			action: func(p *Player) error {
+				return p.Seek(30 * time.Second)
+			},
+			givenCanSeek:  true,
+			givenPosition: 10000000,
+			givenMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Seek",
+			expectedArgs:   []interface{}{int64(30000000)},
+		}, {
+			name: "Seek back beyond the start",
+			action: func(p *Player) error {
+				return p.Seek(-30 * time.Second)
+			},
+			givenCanSeek:   true,
+			givenPosition:  10000000,
+			givenMetadata:  Metadata{},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Seek",
+			expectedArgs:   []interface{}{int64(-10000000)},
+		}, {
+			name: "Seek without known length",
+			action: func(p *Player) error {
+				return p.Seek(time.Hour)
+			},
+			givenCanSeek:   true,
+			givenMetadata:  Metadata{},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.Seek",
+			expectedArgs:   []interface{}{int64(3600000000)},
+		}, {
+			name: "Seek beyond the end",
+			action: func(p *Player) error {
+				return p.Seek(time.Minute)
+			},
+			givenCanSeek:  true,
+			givenPosition: 10000000,
+			givenMetadata: Metadata{
+				"mpris:length": dbus.MakeVariant(int64(60000000)),
+			},
+			expectedErr:   "position 1m10s is beyond the end of the track (1m0s): the position is out of range",
+			expectedErrIs: ErrPositionOutOfRange,
+		}, {
+			name: "Seek not supported",
+			action: func(p *Player) error {
+				return p.Seek(time.Second)
+			},
+			givenCanSeek:  false,
+			expectedErr:   "the player can not seek",
+			expectedErrIs: ErrSeekNotSupported,
+		}, {
+			name: "Seek get property error",
+			action: func(p *Player) error {
+				return p.Seek(time.Second)
+			},
+			getPropErr:  errors.New("nope"),
+			expectedErr: "failed to get property \"org.mpris.MediaPlayer2.Player.CanSeek\": nope",
+		}, {
+			name: "SetPositionDuration",
+			action: func(p *Player) error {
+				return p.SetPositionDuration("/track/1", 1500*time.Millisecond)
+			},
+			givenCanSeek: true,
+			givenMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.SetPosition",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/1"), int64(1500000)},
+		}, {
+			name: "SetPositionDuration negative",
+			action: func(p *Player) error {
+				return p.SetPositionDuration("/track/1", -time.Second)
+			},
+			givenCanSeek: true,
+			givenMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.SetPosition",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/1"), int64(0)},
+		}, {
+			name: "SetPositionDuration beyond the end",
+			action: func(p *Player) error {
+				return p.SetPositionDuration("/track/1", 2*time.Minute)
+			},
+			givenCanSeek: true,
+			givenMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+			expectedErr:   "position 2m0s is beyond the end of the track (1m0s): the position is out of range",
+			expectedErrIs: ErrPositionOutOfRange,
+		}, {
+			name: "SetPositionDuration of other track",
+			action: func(p *Player) error {
+				return p.SetPositionDuration("/track/2", 2*time.Minute)
+			},
+			givenCanSeek: true,
+			givenMetadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.Player.SetPosition",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/2"), int64(120000000)},
+		}, {
+			name: "SetPositionDuration not supported",
+			action: func(p *Player) error {
+				return p.SetPositionDuration("/track/1", time.Second)
+			},
+			givenCanSeek:  false,
+			expectedErr:   "the player can not seek",
+			expectedErrIs: ErrSeekNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenMethod string
+			var givenArgs []interface{}
+
+			err := tt.action(&Player{
+				name: "seek",
+				connection: &dbusConnMock{
+					ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, _ dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(_ ...interface{}) error {
+										return nil
+									},
+								}
+							},
+							GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+								switch p {
+								case "org.mpris.MediaPlayer2.Player.CanSeek":
+									return dbus.MakeVariant(tt.givenCanSeek), tt.getPropErr
+								case "org.mpris.MediaPlayer2.Player.Position":
+									return dbus.MakeVariant(tt.givenPosition), tt.getPropErr
+								case "org.mpris.MediaPlayer2.Player.Metadata":
+									return dbus.MakeVariant(map[string]dbus.Variant(tt.givenMetadata)), tt.getPropErr
+								}
+								t.Fatalf("unexpected property %q", p)
+								return dbus.Variant{}, nil
+							},
+						}
+					},
+				},
+			})
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			if tt.expectedErrIs != nil {
+				assert.ErrorIs(t, err, tt.expectedErrIs)
+			}
+			assert.Equal(t, tt.expectedMethod, givenMethod, "given method is not as expected")
+			assert.EqualValues(t, tt.expectedArgs, givenArgs, "given args is not as expected")
+		})
+	}
+}
+
 func TestPlayer_Close(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -772,39 +1066,68 @@ func TestPlayer_Seeked(t *testing.T) {
 
 		givenSignals []*dbus.Signal
 
+		nameOwnerErr                    error
 		addMatchSignalErr               error
 		expectedAddMatchSignalCallCount int
 		expectedSignalCallCount         int
+		expectedRemoveCallCount         int
 
 		expectedErr       string
-		expectedPositions []int
+		expectedPositions []int64
 	}{
 		{
 			name:                            "happycase",
 			expectedAddMatchSignalCallCount: 1,
 			expectedSignalCallCount:         1,
+			expectedRemoveCallCount:         1,
 			givenSignals: []*dbus.Signal{
 				{
-					Name: "org.mpris.MediaPlayer2.Player.Seeked",
-					Body: []interface{}{int64(1111)},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(1111)},
 				}, {
-					Name: "unknown name",
-					Body: []interface{}{int64(22222)},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "unknown name",
+					Body:   []interface{}{int64(22222)},
 				}, { // multiple body infos
-					Name: "org.mpris.MediaPlayer2.Player.Seeked",
-					Body: []interface{}{int64(333111), int64(333222)},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(333111), int64(333222)},
 				}, { // invalid body type
-					Name: "org.mpris.MediaPlayer2.Player.Seeked",
-					Body: []interface{}{"4444444"},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{"4444444"},
+				}, { // other player
+					Sender: ":1.43",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(666)},
+				}, { // other object path
+					Sender: ":1.42",
+					Path:   "/other",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(777)},
 				}, {
-					Name: "org.mpris.MediaPlayer2.Player.Seeked",
-					Body: []interface{}{int64(55555555)},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(55555555)},
 				},
 			},
-			expectedPositions: []int{
+			expectedPositions: []int64{
 				1111,
 				55555555,
 			},
+		}, {
+			name:                            "name owner error",
+			nameOwnerErr:                    errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 0,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to get owner of \"org.mpris.MediaPlayer2.spotify\": unexpected error",
 		}, {
 			name:                            "add match signal error",
 			addMatchSignalErr:               errors.New("unexpected error"),
@@ -813,8 +1136,10 @@ func TestPlayer_Seeked(t *testing.T) {
 			expectedErr:                     "failed to add signal match option: unexpected error",
 			givenSignals: []*dbus.Signal{
 				{
-					Name: "org.mpris.MediaPlayer2.Player.Seeked",
-					Body: []interface{}{int64(1111)},
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(1111)},
 				},
 			},
 		},
@@ -825,10 +1150,27 @@ func TestPlayer_Seeked(t *testing.T) {
 			testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 			defer cancel()
 
+			var givenOwnerRequest []interface{}
 			mock := &dbusConnMock{
+				ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+					return &dbusBusObjectMock{
+						CallWithContextFunc: func(_ context.Context, method string, _ dbus.Flags, args ...interface{}) dbusCall {
+							givenOwnerRequest = append([]interface{}{dest, path, method}, args...)
+							return &dbusCallMock{
+								StoreFunc: func(retvalues ...interface{}) error {
+									*retvalues[0].(*string) = ":1.42"
+									return tt.nameOwnerErr
+								},
+							}
+						},
+					}
+				},
 				AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
 					return tt.addMatchSignalErr
 				},
+				RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return nil
+				},
 				SignalFunc: func(ch chan<- *dbus.Signal) {
 					go func() {
 						for _, sig := range tt.givenSignals {
@@ -836,14 +1178,16 @@ func TestPlayer_Seeked(t *testing.T) {
 						}
 					}()
 				},
+				RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
 			}
 
 			poss, err := Player{
+				name:       "org.mpris.MediaPlayer2.spotify",
 				connection: mock,
 			}.Seeked(testCtx)
 			require.Equal(t, tt.expectedErr, msgOrEmpty(err))
 
-			var collectedPoss []int
+			var collectedPoss []int64
 			if poss != nil {
 				for {
 					p, ok := <-poss
@@ -854,12 +1198,79 @@ func TestPlayer_Seeked(t *testing.T) {
 				}
 			}
 			assert.EqualValues(t, tt.expectedPositions, collectedPoss)
+			assert.Equal(t, []interface{}{
+				"org.freedesktop.DBus",
+				dbus.ObjectPath("/org/freedesktop/DBus"),
+				"org.freedesktop.DBus.GetNameOwner",
+				"org.mpris.MediaPlayer2.spotify",
+			}, givenOwnerRequest)
 			assert.Equal(t, tt.expectedAddMatchSignalCallCount, len(mock.AddMatchSignalCalls()))
+			if tt.expectedAddMatchSignalCallCount > 0 {
+				assert.Equal(t, []dbus.MatchOption{
+					dbus.WithMatchSender("org.mpris.MediaPlayer2.spotify"),
+					dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
+					dbus.WithMatchInterface("org.mpris.MediaPlayer2.Player"),
+					dbus.WithMatchMember("Seeked"),
+				}, mock.AddMatchSignalCalls()[0].MatchOptions)
+			}
 			assert.Equal(t, tt.expectedSignalCallCount, len(mock.SignalCalls()))
+			assert.Equal(t, tt.expectedRemoveCallCount, len(mock.RemoveSignalCalls()))
+			assert.Equal(t, tt.expectedRemoveCallCount, len(mock.RemoveMatchSignalCalls()))
+			if tt.expectedRemoveCallCount > 0 {
+				assert.Equal(t, mock.AddMatchSignalCalls()[0].MatchOptions, mock.RemoveMatchSignalCalls()[0].MatchOptions)
+			}
 		})
 	}
 }
 
+func TestPlayer_SeekedDuration(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	mock := &dbusConnMock{
+		ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+			return &dbusBusObjectMock{
+				CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+					return &dbusCallMock{
+						StoreFunc: func(retvalues ...interface{}) error {
+							*retvalues[0].(*string) = ":1.42"
+							return nil
+						},
+					}
+				},
+			}
+		},
+		AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		SignalFunc: func(ch chan<- *dbus.Signal) {
+			go func() {
+				ch <- &dbus.Signal{
+					Sender: ":1.42",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(5000000000)},
+				}
+			}()
+		},
+		RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+	}
+
+	poss, err := Player{
+		name:       "org.mpris.MediaPlayer2.spotify",
+		connection: mock,
+	}.SeekedDuration(testCtx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5000*time.Second, <-poss)
+	cancel()
+	for range poss { // wait until closed
+	}
+}
+
 func TestNewPlayer(t *testing.T) {
 	oldDbusSessionBus := dbusSessionBus
 	defer func() {
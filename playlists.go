@@ -0,0 +1,166 @@
+package mpris
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	playlistsInterface              = "org.mpris.MediaPlayer2.Playlists"
+	playlistsActivatePlaylistMethod = playlistsInterface + ".ActivatePlaylist"
+	playlistsGetPlaylistsMethod     = playlistsInterface + ".GetPlaylists"
+	playlistsPlaylistCountProperty  = playlistsInterface + ".PlaylistCount"
+	playlistsOrderingsProperty      = playlistsInterface + ".Orderings"
+	playlistsActivePlaylistProperty = playlistsInterface + ".ActivePlaylist"
+	playlistsPlaylistChangedMember  = "PlaylistChanged"
+)
+
+// Playlists is an implementation of dbus org.mpris.MediaPlayer2.Playlists. see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html
+// Use Player.Playlists to get the Playlists of a Player. It is only available when the media player implements it.
+// Playlists shares the dbus connection of its Player, closing the Player also closes the Playlists.
+type Playlists struct {
+	player Player
+}
+
+// Playlists returns the Playlists of the player.
+func (p Player) Playlists() Playlists {
+	return Playlists{
+		player: p,
+	}
+}
+
+// ActivatePlaylist starts playing the given playlist.
+// Parameters:
+// - playlistID (The id of the playlist to activate.)
+// Note that this must be implemented. If the media player does not allow clients to change the playlist, it should not
+// implement this interface at all.
+// It is up to the media player whether this completely replaces the current tracklist, or whether it is merely inserted
+// into the tracklist and the first track starts. For example, if the media player is operating in a "jukebox" mode, it
+// may just append the playlist to the list of upcoming tracks, and skip to the first track in the playlist.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Method:ActivatePlaylist
+func (p Playlists) ActivatePlaylist(playlistID dbus.ObjectPath) error {
+	return p.ActivatePlaylistContext(context.Background(), playlistID)
+}
+
+// ActivatePlaylistContext is like ActivatePlaylist but uses the given context for the dbus call.
+func (p Playlists) ActivatePlaylistContext(ctx context.Context, playlistID dbus.ObjectPath) error {
+	return p.player.call(ctx, playlistsActivatePlaylistMethod, playlistID)
+}
+
+// GetPlaylists gets a set of playlists.
+// Parameters:
+// - index (The index of the first playlist to be fetched (according to the ordering).)
+// - maxCount (The maximum number of playlists to fetch.)
+// - order (The ordering that should be used.)
+// - reverseOrder (Whether the order should be reversed.)
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Method:GetPlaylists
+func (p Playlists) GetPlaylists(index, maxCount uint32, order PlaylistOrdering, reverseOrder bool) ([]Playlist, error) {
+	return p.GetPlaylistsContext(context.Background(), index, maxCount, order, reverseOrder)
+}
+
+// GetPlaylistsContext is like GetPlaylists but uses the given context for the dbus call.
+func (p Playlists) GetPlaylistsContext(ctx context.Context, index, maxCount uint32, order PlaylistOrdering, reverseOrder bool) ([]Playlist, error) {
+	var playlists []Playlist
+	err := p.player.callAndStore(ctx, playlistsGetPlaylistsMethod, []interface{}{index, maxCount, string(order), reverseOrder}, &playlists)
+	if err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+// PlaylistCount returns the number of playlists available.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Property:PlaylistCount
+func (p Playlists) PlaylistCount() (uint32, error) {
+	return p.PlaylistCountContext(context.Background())
+}
+
+// PlaylistCountContext is like PlaylistCount but uses the given context for the dbus call.
+func (p Playlists) PlaylistCountContext(ctx context.Context) (uint32, error) {
+	v, err := p.player.getProperty(ctx, playlistsPlaylistCountProperty)
+	if err != nil {
+		return 0, err
+	}
+	return decodeUint32(playlistsPlaylistCountProperty, v)
+}
+
+// Orderings returns the available orderings. At least one must be offered.
+// Media players may not return playlists in the requested ordering when it is not listed here.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Property:Orderings
+func (p Playlists) Orderings() ([]PlaylistOrdering, error) {
+	return p.OrderingsContext(context.Background())
+}
+
+// OrderingsContext is like Orderings but uses the given context for the dbus call.
+func (p Playlists) OrderingsContext(ctx context.Context) ([]PlaylistOrdering, error) {
+	v, err := p.player.getProperty(ctx, playlistsOrderingsProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	orderings, err := decodeValue[[]string](playlistsOrderingsProperty, v)
+	if err != nil {
+		return nil, err
+	}
+
+	o := make([]PlaylistOrdering, len(orderings))
+	for i, ordering := range orderings {
+		o[i] = PlaylistOrdering(ordering)
+	}
+
+	return o, nil
+}
+
+// ActivePlaylist returns the currently-active playlist.
+// If there is no currently-active playlist, MaybePlaylist.Valid is false.
+// If the media player does not have the concept of an "active" playlist, MaybePlaylist.Valid will always be false.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Property:ActivePlaylist
+func (p Playlists) ActivePlaylist() (MaybePlaylist, error) {
+	return p.ActivePlaylistContext(context.Background())
+}
+
+// ActivePlaylistContext is like ActivePlaylist but uses the given context for the dbus call.
+func (p Playlists) ActivePlaylistContext(ctx context.Context) (MaybePlaylist, error) {
+	v, err := p.player.getProperty(ctx, playlistsActivePlaylistProperty)
+	if err != nil {
+		return MaybePlaylist{}, err
+	}
+
+	var mp MaybePlaylist
+	err = dbus.Store([]interface{}{v.Value()}, &mp)
+	if err != nil {
+		return MaybePlaylist{}, notParsable(playlistsActivePlaylistProperty, v, "(b(oss))")
+	}
+
+	return mp, nil
+}
+
+// PlaylistChanged emits a Playlist whenever its name or icon changed until the given context is done. The returned
+// channel will be closed afterwards.
+// Client implementations should be aware that this signal may not be implemented.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Signal:PlaylistChanged
+func (p Playlists) PlaylistChanged(ctx context.Context) (<-chan Playlist, error) {
+	playlists := make(chan Playlist)
+	err := p.player.subscribe(ctx, playlistsInterface, playlistsPlaylistChangedMember, func(sig *dbus.Signal) {
+		if len(sig.Body) != 1 { // invalid event
+			return
+		}
+		var playlist Playlist
+		if dbus.Store(sig.Body, &playlist) != nil { // broken signal
+			return
+		}
+
+		select {
+		case playlists <- playlist:
+		case <-ctx.Done():
+		}
+	}, func() {
+		close(playlists)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
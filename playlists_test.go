@@ -0,0 +1,313 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayer_Playlists(t *testing.T) {
+	p := Player{name: "playlists", connection: &dbusConnMock{}}
+
+	assert.Equal(t, Playlists{player: p}, p.Playlists())
+}
+
+func TestPlaylists_ActivatePlaylist(t *testing.T) {
+	tests := []struct {
+		name        string
+		storeErr    error
+		expectedErr string
+	}{
+		{
+			name: "happycase",
+		}, {
+			name:        "error",
+			storeErr:    errors.New("nope"),
+			expectedErr: "failed to call method \"org.mpris.MediaPlayer2.Playlists.ActivatePlaylist\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenDest string
+			var givenPath dbus.ObjectPath
+			var givenMethod string
+			var givenArgs []interface{}
+
+			err := Player{
+				name: "playlists",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						givenDest = dest
+						givenPath = path
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(_ ...interface{}) error {
+										return tt.storeErr
+									},
+								}
+							},
+						}
+					},
+				},
+			}.Playlists().ActivatePlaylist("/playlist/1")
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, "playlists", givenDest)
+			assert.Equal(t, dbus.ObjectPath("/org/mpris/MediaPlayer2"), givenPath)
+			assert.Equal(t, "org.mpris.MediaPlayer2.Playlists.ActivatePlaylist", givenMethod)
+			assert.Equal(t, []interface{}{dbus.ObjectPath("/playlist/1")}, givenArgs)
+		})
+	}
+}
+
+func TestPlaylists_GetPlaylists(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenPlaylists    []Playlist
+		storeErr          error
+		expectedPlaylists []Playlist
+		expectedErr       string
+	}{
+		{
+			name: "happycase",
+			givenPlaylists: []Playlist{
+				{ID: "/playlist/1", Name: "Favorites", Icon: "file://icon.png"},
+				{ID: "/playlist/2", Name: "Rock"},
+			},
+			expectedPlaylists: []Playlist{
+				{ID: "/playlist/1", Name: "Favorites", Icon: "file://icon.png"},
+				{ID: "/playlist/2", Name: "Rock"},
+			},
+		}, {
+			name:        "error",
+			storeErr:    errors.New("nope"),
+			expectedErr: "failed to call method \"org.mpris.MediaPlayer2.Playlists.GetPlaylists\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenMethod string
+			var givenArgs []interface{}
+
+			playlists, err := Player{
+				name: "playlists",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(retvalues ...interface{}) error {
+										require.Len(t, retvalues, 1)
+										*retvalues[0].(*[]Playlist) = tt.givenPlaylists
+										return tt.storeErr
+									},
+								}
+							},
+						}
+					},
+				},
+			}.Playlists().GetPlaylists(5, 10, PlaylistOrderingLastPlayDate, true)
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedPlaylists, playlists)
+			assert.Equal(t, "org.mpris.MediaPlayer2.Playlists.GetPlaylists", givenMethod)
+			assert.Equal(t, []interface{}{uint32(5), uint32(10), "Played", true}, givenArgs)
+		})
+	}
+}
+
+func TestPlaylists_GetProperties(t *testing.T) {
+	tests := []struct {
+		name           string
+		callVariant    dbus.Variant
+		callError      error
+		runAndValidate func(t *testing.T, p Playlists)
+		expectedKey    string
+	}{
+		{
+			name:        "PlaylistCount",
+			callVariant: dbus.MakeVariant(uint32(42)),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				c, err := p.PlaylistCount()
+				assert.NoError(t, err)
+				assert.Equal(t, uint32(42), c)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.PlaylistCount",
+		}, {
+			name:      "PlaylistCount error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				_, err := p.PlaylistCount()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Playlists.PlaylistCount\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.PlaylistCount",
+		}, {
+			name:        "Orderings",
+			callVariant: dbus.MakeVariant([]string{"Alphabetical", "User"}),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				o, err := p.Orderings()
+				assert.NoError(t, err)
+				assert.Equal(t, []PlaylistOrdering{PlaylistOrderingAlphabetical, PlaylistOrderingUserDefined}, o)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.Orderings",
+		}, {
+			name:      "Orderings error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				_, err := p.Orderings()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Playlists.Orderings\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.Orderings",
+		}, {
+			name: "ActivePlaylist",
+			callVariant: dbus.MakeVariantWithSignature(
+				[]interface{}{true, []interface{}{dbus.ObjectPath("/playlist/1"), "Favorites", "file://icon.png"}},
+				dbus.ParseSignatureMust("(b(oss))"),
+			),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				mp, err := p.ActivePlaylist()
+				assert.NoError(t, err)
+				assert.Equal(t, MaybePlaylist{
+					Valid:    true,
+					Playlist: Playlist{ID: "/playlist/1", Name: "Favorites", Icon: "file://icon.png"},
+				}, mp)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.ActivePlaylist",
+		}, {
+			name: "ActivePlaylist no active playlist",
+			callVariant: dbus.MakeVariantWithSignature(
+				[]interface{}{false, []interface{}{dbus.ObjectPath("/"), "", ""}},
+				dbus.ParseSignatureMust("(b(oss))"),
+			),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				mp, err := p.ActivePlaylist()
+				assert.NoError(t, err)
+				assert.Equal(t, MaybePlaylist{Playlist: Playlist{ID: "/"}}, mp)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.ActivePlaylist",
+		}, {
+			name:        "ActivePlaylist invalid type",
+			callVariant: dbus.MakeVariant("nope"),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				_, err := p.ActivePlaylist()
+				assert.ErrorIs(t, err, ErrTypeNotParsable)
+				assert.EqualError(t, err, "property \"org.mpris.MediaPlayer2.Playlists.ActivePlaylist\" of type \"s\" could not be parsed to (b(oss)): the given type is not as expected")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.ActivePlaylist",
+		}, {
+			name:      "ActivePlaylist error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p Playlists) {
+				_, err := p.ActivePlaylist()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Playlists.ActivePlaylist\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Playlists.ActivePlaylist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calledKey string
+
+			tt.runAndValidate(t, Player{
+				name: "playlists",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+								calledKey = p
+								return tt.callVariant, tt.callError
+							},
+						}
+					},
+				},
+			}.Playlists())
+
+			assert.Equal(t, tt.expectedKey, calledKey, "called key is not as expected")
+		})
+	}
+}
+
+func TestPlaylists_PlaylistChanged(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	mock := &dbusConnMock{
+		ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+			return &dbusBusObjectMock{
+				CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+					return &dbusCallMock{
+						StoreFunc: func(retvalues ...interface{}) error {
+							*retvalues[0].(*string) = ":1.7"
+							return nil
+						},
+					}
+				},
+			}
+		},
+		AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		SignalFunc: func(ch chan<- *dbus.Signal) {
+			go func() {
+				for _, sig := range []*dbus.Signal{
+					{
+						Sender: ":1.7",
+						Path:   "/org/mpris/MediaPlayer2",
+						Name:   "org.mpris.MediaPlayer2.Playlists.PlaylistChanged",
+						Body:   []interface{}{[]interface{}{dbus.ObjectPath("/playlist/1"), "Favorites", ""}},
+					}, { // other member
+						Sender: ":1.7",
+						Path:   "/org/mpris/MediaPlayer2",
+						Name:   "org.mpris.MediaPlayer2.Playlists.Unknown",
+						Body:   []interface{}{[]interface{}{dbus.ObjectPath("/playlist/2"), "Unknown", ""}},
+					}, { // invalid body type
+						Sender: ":1.7",
+						Path:   "/org/mpris/MediaPlayer2",
+						Name:   "org.mpris.MediaPlayer2.Playlists.PlaylistChanged",
+						Body:   []interface{}{"nope"},
+					}, {
+						Sender: ":1.7",
+						Path:   "/org/mpris/MediaPlayer2",
+						Name:   "org.mpris.MediaPlayer2.Playlists.PlaylistChanged",
+						Body:   []interface{}{[]interface{}{dbus.ObjectPath("/playlist/3"), "Rock", "file://rock.png"}},
+					},
+				} {
+					ch <- sig
+				}
+			}()
+		},
+		RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+	}
+
+	playlists, err := Player{name: "playlists", connection: mock}.Playlists().PlaylistChanged(testCtx)
+	require.NoError(t, err)
+
+	assert.Equal(t, Playlist{ID: "/playlist/1", Name: "Favorites"}, <-playlists)
+	assert.Equal(t, Playlist{ID: "/playlist/3", Name: "Rock", Icon: "file://rock.png"}, <-playlists)
+	cancel()
+	for range playlists { // wait until closed
+	}
+
+	assert.Equal(t, []dbus.MatchOption{
+		dbus.WithMatchSender("playlists"),
+		dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
+		dbus.WithMatchInterface("org.mpris.MediaPlayer2.Playlists"),
+		dbus.WithMatchMember("PlaylistChanged"),
+	}, mock.AddMatchSignalCalls()[0].MatchOptions)
+}
@@ -0,0 +1,206 @@
+package mpris
+
+import "context"
+
+const (
+	rootInterface                   = "org.mpris.MediaPlayer2"
+	rootRaiseMethod                 = rootInterface + ".Raise"
+	rootQuitMethod                  = rootInterface + ".Quit"
+	rootCanQuitProperty             = rootInterface + ".CanQuit"
+	rootFullscreenProperty          = rootInterface + ".Fullscreen"
+	rootCanSetFullscreenProperty    = rootInterface + ".CanSetFullscreen"
+	rootCanRaiseProperty            = rootInterface + ".CanRaise"
+	rootHasTrackListProperty        = rootInterface + ".HasTrackList"
+	rootIdentityProperty            = rootInterface + ".Identity"
+	rootDesktopEntryProperty        = rootInterface + ".DesktopEntry"
+	rootSupportedURISchemesProperty = rootInterface + ".SupportedUriSchemes"
+	rootSupportedMimeTypesProperty  = rootInterface + ".SupportedMimeTypes"
+)
+
+// Raise brings the media player's user interface to the front using any appropriate mechanism available.
+// The media player may be unable to control how its user interface is displayed, or it may not have a graphical user
+// interface at all. In this case, the CanRaise property is false and this method does nothing.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Method:Raise
+func (p Player) Raise() error {
+	return p.RaiseContext(context.Background())
+}
+
+// RaiseContext is like Raise but uses the given context for the dbus call.
+func (p Player) RaiseContext(ctx context.Context) error {
+	return p.call(ctx, rootRaiseMethod)
+}
+
+// Quit causes the media player to stop running.
+// The media player may refuse to allow clients to shut it down. In this case, the CanQuit property is false and this
+// method does nothing.
+// Note: Media players which can be D-Bus activated, or for which there is no sensibly easy way to terminate a running
+// instance (via the main interface or a notification area icon for example) should allow clients to use this method.
+// Otherwise, it should not be needed.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Method:Quit
+func (p Player) Quit() error {
+	return p.QuitContext(context.Background())
+}
+
+// QuitContext is like Quit but uses the given context for the dbus call.
+func (p Player) QuitContext(ctx context.Context) error {
+	return p.call(ctx, rootQuitMethod)
+}
+
+// CanQuit returns false if calling Quit will have no effect, true otherwise.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:CanQuit
+func (p Player) CanQuit() (bool, error) {
+	return p.CanQuitContext(context.Background())
+}
+
+// CanQuitContext is like CanQuit but uses the given context for the dbus call.
+func (p Player) CanQuitContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, rootCanQuitProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](rootCanQuitProperty, v)
+}
+
+// Fullscreen returns whether the media player is occupying the fullscreen.
+// This is typically used for videos. A value of true indicates that the media player is taking up the full screen.
+// Media centre software may well have this value fixed to true.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:Fullscreen
+func (p Player) Fullscreen() (bool, error) {
+	return p.FullscreenContext(context.Background())
+}
+
+// FullscreenContext is like Fullscreen but uses the given context for the dbus call.
+func (p Player) FullscreenContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, rootFullscreenProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](rootFullscreenProperty, v)
+}
+
+// SetFullscreen sets whether the media player is occupying the fullscreen.
+// If CanSetFullscreen is false, attempting to set this property should have no effect, and may raise an error.
+// However, even if it is true, the media player may still be unable to fulfil the request, in which case attempting to
+// set this property will have no effect (but should not raise an error).
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:Fullscreen
+func (p Player) SetFullscreen(fullscreen bool) error {
+	return p.SetFullscreenContext(context.Background(), fullscreen)
+}
+
+// SetFullscreenContext is like SetFullscreen but uses the given context for the dbus call.
+func (p Player) SetFullscreenContext(ctx context.Context, fullscreen bool) error {
+	return p.setProperty(ctx, rootFullscreenProperty, fullscreen)
+}
+
+// CanSetFullscreen returns false if attempting to set the Fullscreen property (SetFullscreen) will have no effect,
+// true otherwise.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:CanSetFullscreen
+func (p Player) CanSetFullscreen() (bool, error) {
+	return p.CanSetFullscreenContext(context.Background())
+}
+
+// CanSetFullscreenContext is like CanSetFullscreen but uses the given context for the dbus call.
+func (p Player) CanSetFullscreenContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, rootCanSetFullscreenProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](rootCanSetFullscreenProperty, v)
+}
+
+// CanRaise returns false if calling Raise will have no effect, true otherwise.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:CanRaise
+func (p Player) CanRaise() (bool, error) {
+	return p.CanRaiseContext(context.Background())
+}
+
+// CanRaiseContext is like CanRaise but uses the given context for the dbus call.
+func (p Player) CanRaiseContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, rootCanRaiseProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](rootCanRaiseProperty, v)
+}
+
+// HasTrackList returns true whether the media player implements the org.mpris.MediaPlayer2.TrackList interface.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:HasTrackList
+func (p Player) HasTrackList() (bool, error) {
+	return p.HasTrackListContext(context.Background())
+}
+
+// HasTrackListContext is like HasTrackList but uses the given context for the dbus call.
+func (p Player) HasTrackListContext(ctx context.Context) (bool, error) {
+	v, err := p.getProperty(ctx, rootHasTrackListProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](rootHasTrackListProperty, v)
+}
+
+// Identity returns a friendly name to identify the media player to users. This should usually match the name found in
+// .desktop files (eg: "VLC media player").
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:Identity
+func (p Player) Identity() (string, error) {
+	return p.IdentityContext(context.Background())
+}
+
+// IdentityContext is like Identity but uses the given context for the dbus call.
+func (p Player) IdentityContext(ctx context.Context) (string, error) {
+	v, err := p.getProperty(ctx, rootIdentityProperty)
+	if err != nil {
+		return "", err
+	}
+	return decodeValue[string](rootIdentityProperty, v)
+}
+
+// DesktopEntry returns the basename of an installed .desktop file which complies with the Desktop entry specification,
+// with the ".desktop" extension stripped. Example: The desktop entry file is "/usr/share/applications/vlc.desktop",
+// and this property contains "vlc".
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:DesktopEntry
+func (p Player) DesktopEntry() (string, error) {
+	return p.DesktopEntryContext(context.Background())
+}
+
+// DesktopEntryContext is like DesktopEntry but uses the given context for the dbus call.
+func (p Player) DesktopEntryContext(ctx context.Context) (string, error) {
+	v, err := p.getProperty(ctx, rootDesktopEntryProperty)
+	if err != nil {
+		return "", err
+	}
+	return decodeValue[string](rootDesktopEntryProperty, v)
+}
+
+// SupportedURISchemes returns the URI schemes supported by the media player.
+// This can be viewed as protocols supported by the player in almost all cases. Almost every media player will include
+// support for the "file" scheme. Other common schemes are "http" and "rtsp".
+// Note that URI schemes should be lower-case.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:SupportedUriSchemes
+func (p Player) SupportedURISchemes() ([]string, error) {
+	return p.SupportedURISchemesContext(context.Background())
+}
+
+// SupportedURISchemesContext is like SupportedURISchemes but uses the given context for the dbus call.
+func (p Player) SupportedURISchemesContext(ctx context.Context) ([]string, error) {
+	v, err := p.getProperty(ctx, rootSupportedURISchemesProperty)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue[[]string](rootSupportedURISchemesProperty, v)
+}
+
+// SupportedMimeTypes returns the mime-types supported by the media player.
+// Mime-types should be in the standard format (eg: audio/mpeg or application/ogg).
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html#Property:SupportedMimeTypes
+func (p Player) SupportedMimeTypes() ([]string, error) {
+	return p.SupportedMimeTypesContext(context.Background())
+}
+
+// SupportedMimeTypesContext is like SupportedMimeTypes but uses the given context for the dbus call.
+func (p Player) SupportedMimeTypesContext(ctx context.Context) ([]string, error) {
+	v, err := p.getProperty(ctx, rootSupportedMimeTypesProperty)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue[[]string](rootSupportedMimeTypesProperty, v)
+}
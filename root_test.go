@@ -0,0 +1,333 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayer_RootMethods(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenName      string
+		storeErr       error
+		action         func(p *Player) error
+		expectedDest   string
+		expectedPath   dbus.ObjectPath
+		expectedMethod string
+		expectedErr    string
+	}{
+		{
+			name:      "Raise",
+			givenName: "raise",
+			action: func(p *Player) error {
+				return p.Raise()
+			},
+			expectedDest:   "raise",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Raise",
+		}, {
+			name:      "Raise error",
+			givenName: "raise",
+			storeErr:  errors.New("nope"),
+			action: func(p *Player) error {
+				return p.Raise()
+			},
+			expectedDest:   "raise",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Raise",
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.Raise\": nope",
+		}, {
+			name:      "Quit",
+			givenName: "quit",
+			action: func(p *Player) error {
+				return p.Quit()
+			},
+			expectedDest:   "quit",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Quit",
+		}, {
+			name:      "Quit error",
+			givenName: "quit",
+			storeErr:  errors.New("nope"),
+			action: func(p *Player) error {
+				return p.Quit()
+			},
+			expectedDest:   "quit",
+			expectedPath:   "/org/mpris/MediaPlayer2",
+			expectedMethod: "org.mpris.MediaPlayer2.Quit",
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.Quit\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenDest string
+			var givenPath dbus.ObjectPath
+			var givenMethod string
+
+			err := tt.action(&Player{
+				name: tt.givenName,
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						givenDest = dest
+						givenPath = path
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								return &dbusCallMock{
+									StoreFunc: func(_ ...interface{}) error {
+										return tt.storeErr
+									},
+								}
+							},
+						}
+					},
+				},
+			})
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedDest, givenDest, "given dest is not as expected")
+			assert.Equal(t, tt.expectedPath, givenPath, "given path is not as expected")
+			assert.Equal(t, tt.expectedMethod, givenMethod, "given method is not as expected")
+		})
+	}
+}
+
+func TestPlayer_RootGetProperties(t *testing.T) {
+	tests := []struct {
+		name           string
+		callVariant    dbus.Variant
+		callError      error
+		runAndValidate func(t *testing.T, p *Player)
+		expectedKey    string
+	}{
+		{
+			name:        "CanQuit",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, p *Player) {
+				b, err := p.CanQuit()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanQuit",
+		}, {
+			name:      "CanQuit error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.CanQuit()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.CanQuit\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanQuit",
+		}, {
+			name:        "Fullscreen",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, p *Player) {
+				b, err := p.Fullscreen()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Fullscreen",
+		}, {
+			name:      "Fullscreen error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.Fullscreen()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Fullscreen\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Fullscreen",
+		}, {
+			name:        "CanSetFullscreen",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, p *Player) {
+				b, err := p.CanSetFullscreen()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanSetFullscreen",
+		}, {
+			name:      "CanSetFullscreen error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.CanSetFullscreen()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.CanSetFullscreen\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanSetFullscreen",
+		}, {
+			name:        "CanRaise",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, p *Player) {
+				b, err := p.CanRaise()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanRaise",
+		}, {
+			name:      "CanRaise error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.CanRaise()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.CanRaise\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.CanRaise",
+		}, {
+			name:        "HasTrackList",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, p *Player) {
+				b, err := p.HasTrackList()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.HasTrackList",
+		}, {
+			name:      "HasTrackList error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.HasTrackList()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.HasTrackList\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.HasTrackList",
+		}, {
+			name:        "Identity",
+			callVariant: dbus.MakeVariant("VLC media player"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.Identity()
+				assert.NoError(t, err)
+				assert.Equal(t, "VLC media player", s)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Identity",
+		}, {
+			name:      "Identity error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.Identity()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Identity\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.Identity",
+		}, {
+			name:        "DesktopEntry",
+			callVariant: dbus.MakeVariant("vlc"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.DesktopEntry()
+				assert.NoError(t, err)
+				assert.Equal(t, "vlc", s)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.DesktopEntry",
+		}, {
+			name:      "DesktopEntry error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.DesktopEntry()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.DesktopEntry\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.DesktopEntry",
+		}, {
+			name:        "SupportedURISchemes",
+			callVariant: dbus.MakeVariant([]string{"file", "http"}),
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.SupportedURISchemes()
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"file", "http"}, s)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.SupportedUriSchemes",
+		}, {
+			name:      "SupportedURISchemes error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.SupportedURISchemes()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.SupportedUriSchemes\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.SupportedUriSchemes",
+		}, {
+			name:        "SupportedMimeTypes",
+			callVariant: dbus.MakeVariant([]string{"audio/mpeg", "application/ogg"}),
+			runAndValidate: func(t *testing.T, p *Player) {
+				s, err := p.SupportedMimeTypes()
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"audio/mpeg", "application/ogg"}, s)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.SupportedMimeTypes",
+		}, {
+			name:      "SupportedMimeTypes error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, p *Player) {
+				_, err := p.SupportedMimeTypes()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.SupportedMimeTypes\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.SupportedMimeTypes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calledDest string
+			var calledPath dbus.ObjectPath
+			var calledKey string
+
+			tt.runAndValidate(t, &Player{
+				name: "root",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						calledDest = dest
+						calledPath = path
+						return &dbusBusObjectMock{
+							GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+								calledKey = p
+								return tt.callVariant, tt.callError
+							},
+						}
+					},
+				},
+			})
+
+			assert.Equal(t, "root", calledDest, "called dest is not as expected")
+			assert.Equal(t, dbus.ObjectPath("/org/mpris/MediaPlayer2"), calledPath, "called path is not as expected")
+			assert.Equal(t, tt.expectedKey, calledKey, "called key is not as expected")
+		})
+	}
+}
+
+func TestPlayer_SetFullscreen(t *testing.T) {
+	tests := []struct {
+		name        string
+		callError   error
+		expectedErr string
+	}{
+		{
+			name: "happycase",
+		}, {
+			name:        "error",
+			callError:   errors.New("nope"),
+			expectedErr: "failed to set property \"org.mpris.MediaPlayer2.Fullscreen\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calledProperty string
+			var calledValue interface{}
+
+			err := Player{
+				name: "fullscreen",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							SetPropertyWithContextFunc: func(_ context.Context, p string, v interface{}) error {
+								calledProperty = p
+								calledValue = v
+								return tt.callError
+							},
+						}
+					},
+				},
+			}.SetFullscreen(true)
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, "org.mpris.MediaPlayer2.Fullscreen", calledProperty)
+			assert.Equal(t, dbus.MakeVariant(true), calledValue)
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package mpris
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+const (
+	introspectableInterface       = "org.freedesktop.DBus.Introspectable"
+	dbusErrorNameUnknownInterface = "org.freedesktop.DBus.Error.UnknownInterface"
+	dbusErrorNameUnknownProperty  = "org.freedesktop.DBus.Error.UnknownProperty"
+	dbusErrorNamePropertyReadOnly = "org.freedesktop.DBus.Error.PropertyReadOnly"
+	dbusErrorNameInvalidArgs      = "org.freedesktop.DBus.Error.InvalidArgs"
+	dbusErrorNameFailed           = "org.freedesktop.DBus.Error.Failed"
+)
+
+// PlayerHandler is implemented by applications which want to be controllable as media player via mpris (e.g. by the
+// media widgets of desktop shells). It is exported via Server.
+// The methods are called for the corresponding mpris methods and property setters of the interfaces
+// org.mpris.MediaPlayer2 and org.mpris.MediaPlayer2.Player. They may be called concurrently. All other properties are
+// owned by the Server (see ServerState).
+// Errors returned by the methods are passed to the caller as org.freedesktop.DBus.Error.Failed. A dbus.Error (or
+// *dbus.Error) wrapped by the error is passed as is.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html
+type PlayerHandler interface {
+	// Raise brings the user interface of the media player to the front. It is only called when CanRaise is true.
+	Raise() error
+	// Quit causes the media player to stop running. It is only called when CanQuit is true.
+	Quit() error
+	// SetFullscreen sets whether the media player is occupying the fullscreen. It is only called when
+	// CanSetFullscreen is true. The state is updated when nil is returned.
+	SetFullscreen(fullscreen bool) error
+
+	// Next skips to the next track. It is only called when CanGoNext is true.
+	Next() error
+	// Previous skips to the previous track. It is only called when CanGoPrevious is true.
+	Previous() error
+	// Pause pauses playback. It is only called when CanPause is true.
+	Pause() error
+	// PlayPause pauses or resumes playback. It is only called when CanPause is true.
+	PlayPause() error
+	// Stop stops playback. It is only called when CanControl is true.
+	Stop() error
+	// Play starts or resumes playback. It is only called when CanPlay is true.
+	Play() error
+	// Seek seeks forward in the current track by the given offset. A negative offset seeks back. It is only called
+	// when CanSeek is true. Use Server.EmitSeeked to announce the new position.
+	Seek(offset time.Duration) error
+	// SetPosition sets the position of the current track. It is only called when CanSeek is true, trackID is the
+	// mpris:trackid of the current track and position is within the track (0 <= position <= mpris:length). Use
+	// Server.EmitSeeked to announce the new position.
+	SetPosition(trackID dbus.ObjectPath, position time.Duration) error
+	// OpenURI opens the given uri.
+	OpenURI(uri string) error
+	// SetLoopStatus sets the loop status. It is only called when CanControl is true and with a valid LoopStatus. The
+	// state is updated when nil is returned.
+	SetLoopStatus(status LoopStatus) error
+	// SetRate sets the playback rate. It is only called when CanControl is true and with a rate between MinimumRate
+	// and MaximumRate. A rate of 0 calls Pause instead. The state is updated when nil is returned.
+	SetRate(rate float64) error
+	// SetShuffle enables or disables shuffling. It is only called when CanControl is true. The state is updated when
+	// nil is returned.
+	SetShuffle(shuffle bool) error
+	// SetVolume sets the volume. It is only called when CanControl is true. Negative volumes are set to 0. The state
+	// is updated when nil is returned.
+	SetVolume(volume float64) error
+	// Position returns the position of the current track. It is read on demand as it changes continuously.
+	Position() time.Duration
+}
+
+//go:generate moq -out dbus-server-conn_moq_test.go . dbusServerConn
+type dbusServerConn interface {
+	ExportWithMap(v interface{}, mapping map[string]string, path dbus.ObjectPath, iface string) error
+	RequestName(name string, flags dbus.RequestNameFlags) (dbus.RequestNameReply, error)
+	ReleaseName(name string) (dbus.ReleaseNameReply, error)
+	Emit(path dbus.ObjectPath, name string, values ...interface{}) error
+	Close() error
+}
+
+// Server exports a PlayerHandler as media player on the bus, so it can be controlled via mpris (e.g. by the media
+// widgets of desktop shells). The interfaces org.mpris.MediaPlayer2 and org.mpris.MediaPlayer2.Player are exported on
+// the object path /org/mpris/MediaPlayer2 and the bus name org.mpris.MediaPlayer2.<name> is claimed.
+// The Server owns the state of the media player (see ServerState). Changes of the state via Update are announced via
+// org.freedesktop.DBus.Properties.PropertiesChanged.
+// The interfaces org.mpris.MediaPlayer2.TrackList and org.mpris.MediaPlayer2.Playlists are exported additionally if
+// the handler implements TrackListHandler (see Server.TrackList) or PlaylistsHandler (see Server.Playlists).
+// Use NewServer to create a new instance with a connected session-bus via dbus.SessionBus.
+// Use NewServerWithConnection when you want to use a self-configured dbus.Conn.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/
+type Server struct {
+	busName    string
+	handler    PlayerHandler
+	connection dbusServerConn
+	properties map[string]serverProperty
+	trackList  *ServerTrackList
+	playlists  *ServerPlaylists
+
+	mu    sync.Mutex
+	state ServerState
+}
+
+// NewServer exports the given handler with the given initial state as media player on the session-bus (via
+// dbus.SessionBus) and claims the bus name org.mpris.MediaPlayer2.<name> (e.g. "vlc" or "vlc.instance1234" when
+// running multiple instances).
+// An error wrapping ErrNameTaken is returned when the bus name is already owned by another connection.
+// Don't forget to Server.Close() the server after use.
+func NewServer(name string, handler PlayerHandler, state ServerState) (*Server, error) {
+	connection, err := dbusSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to session-bus: %w", err)
+	}
+
+	return newServer(name, handler, state, connection)
+}
+
+// NewServerWithConnection is like NewServer but uses the given connection. The interfaces are unexported again if an
+// error is returned, so the connection can be reused.
+func NewServerWithConnection(name string, handler PlayerHandler, state ServerState, connection *dbus.Conn) (*Server, error) {
+	return newServer(name, handler, state, connection)
+}
+
+func newServer(name string, handler PlayerHandler, state ServerState, connection dbusServerConn) (*Server, error) {
+	s := &Server{
+		busName:    busNamePrefix + name,
+		handler:    handler,
+		connection: connection,
+		state:      state.clone(),
+	}
+	s.state.normalize()
+	s.properties = s.playerProperties()
+
+	interfaces := []introspect.Interface{introspect.IntrospectData, prop.IntrospectData, rootIntrospectData, playerIntrospectData}
+	exports := []serverExport{
+		{v: serverProperties{server: s}, iface: propertiesInterface},
+		{v: serverRoot{server: s}, iface: rootInterface},
+		{v: serverPlayer{server: s}, mapping: playerMethodNames, iface: playerInterface},
+	}
+	if trackListHandler, ok := handler.(TrackListHandler); ok {
+		s.trackList = newServerTrackList(trackListHandler, connection)
+		maps.Copy(s.properties, s.trackListProperties())
+		interfaces = append(interfaces, trackListIntrospectData)
+		exports = append(exports, serverExport{v: serverTrackList{trackList: s.trackList}, iface: trackListInterface})
+	}
+	if playlistsHandler, ok := handler.(PlaylistsHandler); ok {
+		s.playlists = newServerPlaylists(playlistsHandler, connection)
+		maps.Copy(s.properties, s.playlistsProperties())
+		interfaces = append(interfaces, playlistsIntrospectData)
+		exports = append(exports, serverExport{v: serverPlaylists{playlists: s.playlists}, iface: playlistsInterface})
+	}
+	node := &introspect.Node{Name: playerObjectPath, Interfaces: interfaces}
+	exports = append([]serverExport{{v: introspect.NewIntrospectable(node), iface: introspectableInterface}}, exports...)
+
+	for i, e := range exports {
+		err := connection.ExportWithMap(e.v, e.mapping, playerObjectPath, e.iface)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to export %q: %w", e.iface, err), unexport(connection, exports[:i]))
+		}
+	}
+
+	reply, err := connection.RequestName(s.busName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to request name %q: %w", s.busName, err), unexport(connection, exports))
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner && reply != dbus.RequestNameReplyAlreadyOwner {
+		return nil, errors.Join(fmt.Errorf("failed to request name %q: %w", s.busName, ErrNameTaken), unexport(connection, exports))
+	}
+
+	return s, nil
+}
+
+// unexport removes the given exports from the connection, so it can be used to export another Server after newServer
+// failed.
+func unexport(connection dbusServerConn, exports []serverExport) error {
+	var errs []error
+	for _, e := range exports {
+		err := connection.ExportWithMap(nil, nil, playerObjectPath, e.iface)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to unexport %q: %w", e.iface, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// serverExport is an object exported on playerObjectPath as the given interface. mapping maps the names of the go
+// methods to the names of the dbus methods.
+type serverExport struct {
+	v       interface{}
+	mapping map[string]string
+	iface   string
+}
+
+// BusName returns the claimed bus name. e.g. "org.mpris.MediaPlayer2.vlc"
+func (s *Server) BusName() string {
+	return s.busName
+}
+
+// TrackList returns the tracklist of the media player. It is nil if the handler does not implement TrackListHandler.
+func (s *Server) TrackList() *ServerTrackList {
+	return s.trackList
+}
+
+// Playlists returns the playlists of the media player. It is nil if the handler does not implement PlaylistsHandler.
+func (s *Server) Playlists() *ServerPlaylists {
+	return s.playlists
+}
+
+// State returns a copy of the current state.
+func (s *Server) State() ServerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.clone()
+}
+
+// Update changes the state via the given function and emits one PropertiesChanged signal per interface containing all
+// properties which have been changed. Properties which are not provided anymore (e.g. LoopStatus has been set to "")
+// are announced as invalidated. Nothing is emitted if nothing has been changed.
+// The function is called with a copy of the state which can be modified freely. It must not call other methods of the
+// Server.
+// see: https://dbus.freedesktop.org/doc/dbus-specification.html#standard-interfaces-properties
+func (s *Server) Update(update func(state *ServerState)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state.clone()
+	update(&state)
+	state.normalize()
+
+	old := s.state
+	s.state = state
+
+	var errs []error
+	for _, iface := range []string{rootInterface, playerInterface} {
+		changed, invalidated := s.diff(iface, &old, &state)
+		if len(changed) == 0 && len(invalidated) == 0 {
+			continue
+		}
+
+		errs = append(errs, emitPropertiesChanged(s.connection, iface, changed, invalidated))
+	}
+
+	return errors.Join(errs...)
+}
+
+// emitPropertiesChanged emits org.freedesktop.DBus.Properties.PropertiesChanged for the given interface.
+func emitPropertiesChanged(connection dbusServerConn, iface string, changed map[string]dbus.Variant, invalidated []string) error {
+	err := connection.Emit(playerObjectPath, propertiesInterface+"."+propertiesPropertiesChangedMember, iface, changed, invalidated)
+	if err != nil {
+		return fmt.Errorf("failed to emit %q of %q: %w", propertiesPropertiesChangedMember, iface, err)
+	}
+
+	return nil
+}
+
+// EmitSeeked announces that the position of the current track changed in a way which is inconsistent with the current
+// playing state (e.g. after Seek or SetPosition has been handled or when the track has been restarted). The position
+// is not emitted via PropertiesChanged as it changes continuously.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Signal:Seeked
+func (s *Server) EmitSeeked(position time.Duration) error {
+	err := s.connection.Emit(playerObjectPath, playerInterface+"."+playerSeekedMember, position.Microseconds())
+	if err != nil {
+		return fmt.Errorf("failed to emit %q: %w", playerSeekedMember, err)
+	}
+
+	return nil
+}
+
+// Close releases the bus name and closes the dbus connection.
+func (s *Server) Close() error {
+	_, err := s.connection.ReleaseName(s.busName)
+	if err != nil {
+		return fmt.Errorf("failed to release name %q: %w", s.busName, err)
+	}
+
+	err = s.connection.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close dbus connection: %w", err)
+	}
+
+	return nil
+}
+
+// serverProperties implements org.freedesktop.DBus.Properties for the exported interfaces.
+// see: https://dbus.freedesktop.org/doc/dbus-specification.html#standard-interfaces-properties
+type serverProperties struct {
+	server *Server
+}
+
+func (p serverProperties) Get(iface, name string) (dbus.Variant, *dbus.Error) {
+	property, err := p.server.property(iface, name)
+	if err != nil {
+		return dbus.Variant{}, err
+	}
+
+	state := p.server.State()
+	v := property.get(&state)
+	if v == nil {
+		return dbus.Variant{}, dbus.NewError(dbusErrorNameUnknownProperty, []interface{}{fmt.Sprintf("property %q is not provided by the media player", iface+"."+name)})
+	}
+
+	return dbus.MakeVariant(v), nil
+}
+
+func (p serverProperties) GetAll(iface string) (map[string]dbus.Variant, *dbus.Error) {
+	if !p.server.hasInterface(iface) {
+		return nil, dbus.NewError(dbusErrorNameUnknownInterface, []interface{}{fmt.Sprintf("unknown interface %q", iface)})
+	}
+
+	state := p.server.State()
+	values := map[string]dbus.Variant{}
+	for key, property := range p.server.properties {
+		propertyIface, name, _ := splitProperty(key)
+		if propertyIface != iface {
+			continue
+		}
+		if v := property.get(&state); v != nil {
+			values[name] = dbus.MakeVariant(v)
+		}
+	}
+
+	return values, nil
+}
+
+func (p serverProperties) Set(iface, name string, v dbus.Variant) *dbus.Error {
+	property, err := p.server.property(iface, name)
+	if err != nil {
+		return err
+	}
+	if property.set == nil {
+		return dbus.NewError(dbusErrorNamePropertyReadOnly, []interface{}{fmt.Sprintf("property %q is read-only", iface+"."+name)})
+	}
+
+	return handlerError(property.set(v))
+}
+
+func (s *Server) property(iface, name string) (serverProperty, *dbus.Error) {
+	if !s.hasInterface(iface) {
+		return serverProperty{}, dbus.NewError(dbusErrorNameUnknownInterface, []interface{}{fmt.Sprintf("unknown interface %q", iface)})
+	}
+
+	property, ok := s.properties[iface+"."+name]
+	if !ok {
+		return serverProperty{}, dbus.NewError(dbusErrorNameUnknownProperty, []interface{}{fmt.Sprintf("unknown property %q", iface+"."+name)})
+	}
+
+	return property, nil
+}
+
+func (s *Server) hasInterface(iface string) bool {
+	for key := range s.properties {
+		if propertyIface, _, _ := splitProperty(key); propertyIface == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// handlerError converts an error returned by a handler into the dbus error returned to the caller.
+func handlerError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) {
+		return &dbusErr
+	}
+	var dbusErrPtr *dbus.Error
+	if errors.As(err, &dbusErrPtr) {
+		return dbusErrPtr
+	}
+
+	return dbus.NewError(dbusErrorNameFailed, []interface{}{err.Error()})
+}
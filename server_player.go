@@ -0,0 +1,114 @@
+package mpris
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+// serverRoot implements the methods of org.mpris.MediaPlayer2 by delegating to the PlayerHandler.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html
+type serverRoot struct {
+	server *Server
+}
+
+func (r serverRoot) Raise() *dbus.Error {
+	if !r.server.State().CanRaise {
+		return nil
+	}
+	return handlerError(r.server.handler.Raise())
+}
+
+func (r serverRoot) Quit() *dbus.Error {
+	if !r.server.State().CanQuit {
+		return nil
+	}
+	return handlerError(r.server.handler.Quit())
+}
+
+// playerMethodNames maps the names of the methods of serverPlayer to the names of the mpris methods which differ.
+var playerMethodNames = map[string]string{
+	"SeekOffset": "Seek",
+	"OpenURI":    "OpenUri",
+}
+
+// serverPlayer implements the methods of org.mpris.MediaPlayer2.Player by delegating to the PlayerHandler. Methods
+// which are not supported according to the capability properties (e.g. CanGoNext) have no effect.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html
+type serverPlayer struct {
+	server *Server
+}
+
+func (p serverPlayer) Next() *dbus.Error {
+	if !p.server.State().CanGoNext {
+		return nil
+	}
+	return handlerError(p.server.handler.Next())
+}
+
+func (p serverPlayer) Previous() *dbus.Error {
+	if !p.server.State().CanGoPrevious {
+		return nil
+	}
+	return handlerError(p.server.handler.Previous())
+}
+
+func (p serverPlayer) Pause() *dbus.Error {
+	if !p.server.State().CanPause {
+		return nil
+	}
+	return handlerError(p.server.handler.Pause())
+}
+
+func (p serverPlayer) PlayPause() *dbus.Error {
+	if !p.server.State().CanPause {
+		return nil
+	}
+	return handlerError(p.server.handler.PlayPause())
+}
+
+func (p serverPlayer) Stop() *dbus.Error {
+	if !p.server.State().CanControl {
+		return nil
+	}
+	return handlerError(p.server.handler.Stop())
+}
+
+func (p serverPlayer) Play() *dbus.Error {
+	if !p.server.State().CanPlay {
+		return nil
+	}
+	return handlerError(p.server.handler.Play())
+}
+
+// SeekOffset is exported as Seek (see playerMethodNames) because go vet expects methods named Seek to implement
+// io.Seeker.
+func (p serverPlayer) SeekOffset(offset int64) *dbus.Error {
+	if !p.server.State().CanSeek {
+		return nil
+	}
+	return handlerError(p.server.handler.Seek(microseconds(offset)))
+}
+
+// SetPosition ignores requests for other tracks than the current one or for positions outside the current track as
+// specified.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Method:SetPosition
+func (p serverPlayer) SetPosition(trackID dbus.ObjectPath, position int64) *dbus.Error {
+	state := p.server.State()
+	if !state.CanSeek {
+		return nil
+	}
+
+	md := state.Metadata
+	currentTrackID, _ := md.MPRISTrackID()
+	length, _ := md.Length()
+	target := microseconds(position)
+	if trackID != currentTrackID || target < 0 || (length > 0 && target > length) {
+		return nil
+	}
+
+	return handlerError(p.server.handler.SetPosition(trackID, target))
+}
+
+// OpenURI is exported as OpenUri (see playerMethodNames).
+func (p serverPlayer) OpenURI(uri string) *dbus.Error {
+	return handlerError(p.server.handler.OpenURI(uri))
+}
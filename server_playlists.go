@@ -0,0 +1,277 @@
+package mpris
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// PlaylistsHandler can be implemented by a PlayerHandler additionally to export the interface
+// org.mpris.MediaPlayer2.Playlists. The orderings, the active playlist and changes of the playlists are managed via
+// Server.Playlists.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html
+type PlaylistsHandler interface {
+	// Playlists returns all playlists in the user-defined order (see PlaylistOrderingUserDefined). It is called on
+	// demand (e.g. for every GetPlaylists call), so it should be cheap. The returned slice must not be modified
+	// afterwards.
+	Playlists() []ServerPlaylist
+	// ActivatePlaylist starts playing the given playlist. It is only called with playlists returned by Playlists.
+	// The playlist becomes the active playlist when nil is returned.
+	ActivatePlaylist(playlistID dbus.ObjectPath) error
+}
+
+// ServerPlaylist is a playlist of a media player exported by Server. The dates are used to order the playlists, zero
+// dates are ordered first.
+type ServerPlaylist struct {
+	Playlist
+	// Created is the creation date of the playlist (see PlaylistOrderingCreationDate).
+	Created time.Time
+	// Modified is the last modified date of the playlist (see PlaylistOrderingModifiedDate).
+	Modified time.Time
+	// Played is the date the playlist was played last (see PlaylistOrderingLastPlayDate).
+	Played time.Time
+}
+
+// ServerPlaylists manages the properties and signals of the interface org.mpris.MediaPlayer2.Playlists of a media
+// player exported by Server. The playlists itself are read via PlaylistsHandler.Playlists. It is safe for concurrent
+// use.
+// Use Server.Playlists to get the ServerPlaylists of a Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html
+type ServerPlaylists struct {
+	handler    PlaylistsHandler
+	connection dbusServerConn
+
+	mu               sync.Mutex
+	orderings        []PlaylistOrdering
+	activePlaylistID dbus.ObjectPath
+	// playlists and activePlaylist are the values known by clients. They are compared to the current values to emit
+	// changes.
+	playlists      map[dbus.ObjectPath]Playlist
+	activePlaylist MaybePlaylist
+}
+
+func newServerPlaylists(handler PlaylistsHandler, connection dbusServerConn) *ServerPlaylists {
+	p := &ServerPlaylists{
+		handler:    handler,
+		connection: connection,
+		orderings:  []PlaylistOrdering{PlaylistOrderingAlphabetical, PlaylistOrderingUserDefined},
+	}
+	p.playlists, p.activePlaylist = p.current()
+
+	return p
+}
+
+// Orderings returns the orderings announced to clients.
+func (p *ServerPlaylists) Orderings() []PlaylistOrdering {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.orderings)
+}
+
+// SetOrderings sets the orderings announced to clients and emits PropertiesChanged if they changed. At least one
+// ordering is required. Defaults to PlaylistOrderingAlphabetical and PlaylistOrderingUserDefined.
+// GetPlaylists supports all orderings regardless of the announced ones.
+func (p *ServerPlaylists) SetOrderings(orderings []PlaylistOrdering) error {
+	if len(orderings) == 0 {
+		return errors.New("at least one ordering is required")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if slices.Equal(p.orderings, orderings) {
+		return nil
+	}
+	p.orderings = slices.Clone(orderings)
+
+	return emitPropertiesChanged(p.connection, playlistsInterface, map[string]dbus.Variant{"Orderings": dbus.MakeVariant(orderingStrings(orderings))}, []string{})
+}
+
+// ActivePlaylist returns the currently-active playlist. MaybePlaylist.Valid is false if there is none.
+func (p *ServerPlaylists) ActivePlaylist() MaybePlaylist {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.activePlaylistOf(p.handler.Playlists())
+}
+
+// SetActivePlaylist sets the currently-active playlist ("" if there is none) and emits PropertiesChanged if it changed.
+// The active playlist is set automatically when a client activated a playlist successfully.
+func (p *ServerPlaylists) SetActivePlaylist(playlistID dbus.ObjectPath) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.activePlaylistID = playlistID
+
+	return p.emitChanges()
+}
+
+// Refresh announces changes of the playlists returned by PlaylistsHandler.Playlists. It emits PlaylistChanged for
+// every playlist which name or icon changed and PropertiesChanged if PlaylistCount or ActivePlaylist changed.
+func (p *ServerPlaylists) Refresh() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.emitChanges()
+}
+
+// emitChanges emits all changes since the last call. It must be called with p.mu held to emit the signals in order.
+func (p *ServerPlaylists) emitChanges() error {
+	playlists, activePlaylist := p.current()
+
+	var changedPlaylists []Playlist
+	for id, playlist := range playlists {
+		if old, ok := p.playlists[id]; ok && old != playlist {
+			changedPlaylists = append(changedPlaylists, playlist)
+		}
+	}
+	slices.SortFunc(changedPlaylists, func(a, b Playlist) int { return cmp.Compare(a.ID, b.ID) })
+
+	changed := map[string]dbus.Variant{}
+	if len(playlists) != len(p.playlists) {
+		changed["PlaylistCount"] = dbus.MakeVariant(uint32(len(playlists)))
+	}
+	if activePlaylist != p.activePlaylist {
+		changed["ActivePlaylist"] = dbus.MakeVariant(activePlaylist)
+	}
+
+	p.playlists, p.activePlaylist = playlists, activePlaylist
+
+	var errs []error
+	for _, playlist := range changedPlaylists {
+		err := p.connection.Emit(playerObjectPath, playlistsInterface+"."+playlistsPlaylistChangedMember, playlist)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to emit %q: %w", playlistsPlaylistChangedMember, err))
+		}
+	}
+	if len(changed) > 0 {
+		errs = append(errs, emitPropertiesChanged(p.connection, playlistsInterface, changed, []string{}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// current returns the current playlists by id and the active playlist. It must be called with p.mu held.
+func (p *ServerPlaylists) current() (map[dbus.ObjectPath]Playlist, MaybePlaylist) {
+	serverPlaylists := p.handler.Playlists()
+	playlists := make(map[dbus.ObjectPath]Playlist, len(serverPlaylists))
+	for _, playlist := range serverPlaylists {
+		playlists[playlist.ID] = playlist.Playlist
+	}
+
+	return playlists, p.activePlaylistOf(serverPlaylists)
+}
+
+// activePlaylistOf returns the active playlist if it is part of the given playlists. The id of an invalid playlist is
+// "/" as an empty object path can not be sent. It must be called with p.mu held.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Struct:Maybe_Playlist
+func (p *ServerPlaylists) activePlaylistOf(playlists []ServerPlaylist) MaybePlaylist {
+	if p.activePlaylistID != "" {
+		for _, playlist := range playlists {
+			if playlist.ID == p.activePlaylistID {
+				return MaybePlaylist{Valid: true, Playlist: playlist.Playlist}
+			}
+		}
+	}
+
+	return MaybePlaylist{Playlist: Playlist{ID: "/"}}
+}
+
+// sortedPlaylists returns the playlists sorted by the given ordering. The user-defined order is kept for equal
+// playlists. An error is returned for unknown orderings.
+func sortedPlaylists(playlists []ServerPlaylist, ordering PlaylistOrdering, reverse bool) ([]Playlist, error) {
+	var compare func(a, b ServerPlaylist) int
+	switch ordering {
+	case PlaylistOrderingAlphabetical:
+		compare = func(a, b ServerPlaylist) int { return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) }
+	case PlaylistOrderingCreationDate:
+		compare = func(a, b ServerPlaylist) int { return a.Created.Compare(b.Created) }
+	case PlaylistOrderingModifiedDate:
+		compare = func(a, b ServerPlaylist) int { return a.Modified.Compare(b.Modified) }
+	case PlaylistOrderingLastPlayDate:
+		compare = func(a, b ServerPlaylist) int { return a.Played.Compare(b.Played) }
+	case PlaylistOrderingUserDefined:
+		compare = func(ServerPlaylist, ServerPlaylist) int { return 0 }
+	default:
+		return nil, fmt.Errorf("unknown ordering %q", ordering)
+	}
+
+	sorted := slices.Clone(playlists)
+	slices.SortStableFunc(sorted, compare)
+	if reverse {
+		slices.Reverse(sorted)
+	}
+
+	result := make([]Playlist, len(sorted))
+	for i, playlist := range sorted {
+		result[i] = playlist.Playlist
+	}
+
+	return result, nil
+}
+
+func orderingStrings(orderings []PlaylistOrdering) []string {
+	s := make([]string, len(orderings))
+	for i, ordering := range orderings {
+		s[i] = string(ordering)
+	}
+	return s
+}
+
+func (s *Server) playlistsProperties() map[string]serverProperty {
+	p := s.playlists
+	return map[string]serverProperty{
+		playlistsPlaylistCountProperty: {
+			get:      func(*ServerState) interface{} { return uint32(len(p.handler.Playlists())) },
+			volatile: true,
+		},
+		playlistsOrderingsProperty: {
+			get:      func(*ServerState) interface{} { return orderingStrings(p.Orderings()) },
+			volatile: true,
+		},
+		playlistsActivePlaylistProperty: {
+			get:      func(*ServerState) interface{} { return p.ActivePlaylist() },
+			volatile: true,
+		},
+	}
+}
+
+// serverPlaylists implements the methods of org.mpris.MediaPlayer2.Playlists by delegating to the PlaylistsHandler.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html
+type serverPlaylists struct {
+	playlists *ServerPlaylists
+}
+
+func (p serverPlaylists) ActivatePlaylist(playlistID dbus.ObjectPath) *dbus.Error {
+	if !slices.ContainsFunc(p.playlists.handler.Playlists(), func(playlist ServerPlaylist) bool { return playlist.ID == playlistID }) {
+		return dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{fmt.Sprintf("unknown playlist %q", playlistID)})
+	}
+
+	err := p.playlists.handler.ActivatePlaylist(playlistID)
+	if err != nil {
+		return handlerError(err)
+	}
+
+	return handlerError(p.playlists.SetActivePlaylist(playlistID))
+}
+
+// GetPlaylists returns at most maxCount playlists starting at index in the given order.
+func (p serverPlaylists) GetPlaylists(index, maxCount uint32, order string, reverseOrder bool) ([]Playlist, *dbus.Error) {
+	playlists, err := sortedPlaylists(p.playlists.handler.Playlists(), PlaylistOrdering(order), reverseOrder)
+	if err != nil {
+		return nil, dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{err.Error()})
+	}
+
+	count := uint64(len(playlists))
+	start := min(uint64(index), count)
+	end := min(start+uint64(maxCount), count)
+
+	return playlists[start:end], nil
+}
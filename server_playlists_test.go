@@ -0,0 +1,276 @@
+package mpris
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlaylistsHandler is a testHandler which implements PlaylistsHandler additionally.
+type testPlaylistsHandler struct {
+	testHandler
+	playlists []ServerPlaylist
+}
+
+func (h *testPlaylistsHandler) Playlists() []ServerPlaylist {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.playlists
+}
+
+func (h *testPlaylistsHandler) setPlaylists(playlists []ServerPlaylist) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.playlists = playlists
+}
+
+func (h *testPlaylistsHandler) ActivatePlaylist(playlistID dbus.ObjectPath) error {
+	return h.record("ActivatePlaylist", playlistID)
+}
+
+// newTestPlaylists returns the playlists b (user-defined first), C and a, which are created, modified and played in
+// different orders.
+func newTestPlaylists() []ServerPlaylist {
+	date := func(day int) time.Time {
+		return time.Date(2024, time.January, day, 0, 0, 0, 0, time.UTC)
+	}
+	return []ServerPlaylist{
+		{Playlist: Playlist{ID: "/playlist/b", Name: "b"}, Created: date(2), Modified: date(3), Played: date(1)},
+		{Playlist: Playlist{ID: "/playlist/c", Name: "C", Icon: "file:///c.png"}, Created: date(1), Modified: date(2), Played: date(3)},
+		{Playlist: Playlist{ID: "/playlist/a", Name: "a"}, Created: date(3), Modified: date(1), Played: date(2)},
+	}
+}
+
+func playlistsChanged(changed map[string]dbus.Variant) emitted {
+	return emitted{
+		name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+		values: []interface{}{"org.mpris.MediaPlayer2.Playlists", changed, []string{}},
+	}
+}
+
+func TestServerPlaylists_GetPlaylists(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       uint32
+		maxCount    uint32
+		order       string
+		reverse     bool
+		expectedIDs []dbus.ObjectPath
+		expectedErr string
+	}{
+		{
+			name:        "Alphabetical",
+			maxCount:    10,
+			order:       "Alphabetical",
+			expectedIDs: []dbus.ObjectPath{"/playlist/a", "/playlist/b", "/playlist/c"},
+		}, {
+			name:        "Created",
+			maxCount:    10,
+			order:       "Created",
+			expectedIDs: []dbus.ObjectPath{"/playlist/c", "/playlist/b", "/playlist/a"},
+		}, {
+			name:        "Modified",
+			maxCount:    10,
+			order:       "Modified",
+			expectedIDs: []dbus.ObjectPath{"/playlist/a", "/playlist/c", "/playlist/b"},
+		}, {
+			name:        "Played",
+			maxCount:    10,
+			order:       "Played",
+			expectedIDs: []dbus.ObjectPath{"/playlist/b", "/playlist/a", "/playlist/c"},
+		}, {
+			name:        "User",
+			maxCount:    10,
+			order:       "User",
+			expectedIDs: []dbus.ObjectPath{"/playlist/b", "/playlist/c", "/playlist/a"},
+		}, {
+			name:        "reversed",
+			maxCount:    10,
+			order:       "Alphabetical",
+			reverse:     true,
+			expectedIDs: []dbus.ObjectPath{"/playlist/c", "/playlist/b", "/playlist/a"},
+		}, {
+			name:        "paged",
+			index:       1,
+			maxCount:    1,
+			order:       "Alphabetical",
+			expectedIDs: []dbus.ObjectPath{"/playlist/b"},
+		}, {
+			name:        "paged reversed",
+			index:       1,
+			maxCount:    5,
+			order:       "User",
+			reverse:     true,
+			expectedIDs: []dbus.ObjectPath{"/playlist/c", "/playlist/b"},
+		}, {
+			name:        "max count",
+			index:       2,
+			maxCount:    ^uint32(0),
+			order:       "User",
+			expectedIDs: []dbus.ObjectPath{"/playlist/a"},
+		}, {
+			name:        "index out of range",
+			index:       3,
+			maxCount:    10,
+			order:       "User",
+			expectedIDs: []dbus.ObjectPath{},
+		}, {
+			name:        "unknown order",
+			maxCount:    10,
+			order:       "Size",
+			expectedErr: "org.freedesktop.DBus.Error.InvalidArgs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &testPlaylistsHandler{playlists: newTestPlaylists()}
+			p := serverPlaylists{playlists: newServerPlaylists(h, nil)}
+
+			playlists, err := p.GetPlaylists(tt.index, tt.maxCount, tt.order, tt.reverse)
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+				return
+			}
+
+			require.Nil(t, err)
+			ids := make([]dbus.ObjectPath, len(playlists))
+			for i, playlist := range playlists {
+				ids[i] = playlist.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestServerPlaylists_ActivatePlaylist(t *testing.T) {
+	tests := []struct {
+		name            string
+		playlistID      dbus.ObjectPath
+		handlerErr      error
+		expectedCalls   []string
+		expectedActive  MaybePlaylist
+		expectedSignals []emitted
+		expectedErr     string
+	}{
+		{
+			name:           "activated",
+			playlistID:     "/playlist/c",
+			expectedCalls:  []string{"ActivatePlaylist"},
+			expectedActive: MaybePlaylist{Valid: true, Playlist: Playlist{ID: "/playlist/c", Name: "C", Icon: "file:///c.png"}},
+			expectedSignals: []emitted{playlistsChanged(map[string]dbus.Variant{
+				"ActivePlaylist": dbus.MakeVariant(MaybePlaylist{Valid: true, Playlist: Playlist{ID: "/playlist/c", Name: "C", Icon: "file:///c.png"}}),
+			})},
+		}, {
+			name:           "handler error",
+			playlistID:     "/playlist/c",
+			handlerErr:     errors.New("nope"),
+			expectedCalls:  []string{"ActivatePlaylist"},
+			expectedActive: MaybePlaylist{Playlist: Playlist{ID: "/"}},
+			expectedErr:    "org.freedesktop.DBus.Error.Failed",
+		}, {
+			name:           "unknown playlist",
+			playlistID:     "/playlist/z",
+			expectedActive: MaybePlaylist{Playlist: Playlist{ID: "/"}},
+			expectedErr:    "org.freedesktop.DBus.Error.InvalidArgs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &testPlaylistsHandler{testHandler: testHandler{err: tt.handlerErr}, playlists: newTestPlaylists()}
+			connMock, signals := newEmitRecorder(nil)
+			playlists := newServerPlaylists(h, connMock)
+
+			err := serverPlaylists{playlists: playlists}.ActivatePlaylist(tt.playlistID)
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+			} else {
+				assert.Nil(t, err)
+			}
+			calls, _ := h.recorded()
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedActive, playlists.ActivePlaylist())
+			assert.Equal(t, tt.expectedSignals, *signals)
+		})
+	}
+}
+
+func TestServerPlaylists_Refresh(t *testing.T) {
+	h := &testPlaylistsHandler{playlists: newTestPlaylists()}
+	connMock, signals := newEmitRecorder(nil)
+	playlists := newServerPlaylists(h, connMock)
+	require.NoError(t, playlists.SetActivePlaylist("/playlist/a"))
+	*signals = nil
+
+	require.NoError(t, playlists.Refresh())
+	assert.Empty(t, *signals)
+
+	renamed := newTestPlaylists()
+	renamed[0].Name = "B"
+	renamed[2].Name = "A"
+	h.setPlaylists(renamed)
+	require.NoError(t, playlists.Refresh())
+	assert.Equal(t, []emitted{
+		{name: "org.mpris.MediaPlayer2.Playlists.PlaylistChanged", values: []interface{}{Playlist{ID: "/playlist/a", Name: "A"}}},
+		{name: "org.mpris.MediaPlayer2.Playlists.PlaylistChanged", values: []interface{}{Playlist{ID: "/playlist/b", Name: "B"}}},
+		playlistsChanged(map[string]dbus.Variant{"ActivePlaylist": dbus.MakeVariant(MaybePlaylist{Valid: true, Playlist: Playlist{ID: "/playlist/a", Name: "A"}})}),
+	}, *signals)
+	*signals = nil
+
+	h.setPlaylists(renamed[:2])
+	require.NoError(t, playlists.Refresh())
+	assert.Equal(t, []emitted{playlistsChanged(map[string]dbus.Variant{
+		"PlaylistCount":  dbus.MakeVariant(uint32(2)),
+		"ActivePlaylist": dbus.MakeVariant(MaybePlaylist{Playlist: Playlist{ID: "/"}}),
+	})}, *signals)
+}
+
+func TestServerPlaylists_SetOrderings(t *testing.T) {
+	connMock, signals := newEmitRecorder(nil)
+	playlists := newServerPlaylists(&testPlaylistsHandler{}, connMock)
+	assert.Equal(t, []PlaylistOrdering{PlaylistOrderingAlphabetical, PlaylistOrderingUserDefined}, playlists.Orderings())
+
+	require.NoError(t, playlists.SetOrderings([]PlaylistOrdering{PlaylistOrderingAlphabetical, PlaylistOrderingUserDefined}))
+	assert.Empty(t, *signals)
+
+	require.NoError(t, playlists.SetOrderings([]PlaylistOrdering{PlaylistOrderingCreationDate}))
+	assert.Equal(t, []PlaylistOrdering{PlaylistOrderingCreationDate}, playlists.Orderings())
+	assert.Equal(t, []emitted{playlistsChanged(map[string]dbus.Variant{"Orderings": dbus.MakeVariant([]string{"Created"})})}, *signals)
+
+	assert.EqualError(t, playlists.SetOrderings(nil), "at least one ordering is required")
+}
+
+func TestServerPlaylists_EmitError(t *testing.T) {
+	h := &testPlaylistsHandler{playlists: newTestPlaylists()}
+	connMock, _ := newEmitRecorder(errors.New("nope"))
+	playlists := newServerPlaylists(h, connMock)
+
+	renamed := newTestPlaylists()
+	renamed[0].Name = "B"
+	h.setPlaylists(renamed[:1])
+	err := playlists.Refresh()
+	assert.EqualError(t, err, "failed to emit \"PlaylistChanged\": nope\n"+
+		"failed to emit \"PropertiesChanged\" of \"org.mpris.MediaPlayer2.Playlists\": nope")
+}
+
+func TestServerPlaylists_Properties(t *testing.T) {
+	connMock, _ := newEmitRecorder(nil)
+	s := newTestServer(&testPlaylistsHandler{playlists: newTestPlaylists()}, newTestServerState(true), connMock)
+	require.NotNil(t, s.Playlists())
+
+	values, err := serverProperties{server: s}.GetAll("org.mpris.MediaPlayer2.Playlists")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]dbus.Variant{
+		"PlaylistCount":  dbus.MakeVariant(uint32(3)),
+		"Orderings":      dbus.MakeVariant([]string{"Alphabetical", "User"}),
+		"ActivePlaylist": dbus.MakeVariant(MaybePlaylist{Playlist: Playlist{ID: "/"}}),
+	}, values)
+	assert.Equal(t, "(b(oss))", values["ActivePlaylist"].Signature().String())
+}
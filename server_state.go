@@ -0,0 +1,236 @@
+package mpris
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// MediaPlayerState contains the properties of the interface org.mpris.MediaPlayer2 of a media player exported by
+// Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Media_Player.html
+type MediaPlayerState struct {
+	CanQuit          bool
+	Fullscreen       bool
+	CanSetFullscreen bool
+	CanRaise         bool
+	// Identity is a friendly name to identify the media player to users (e.g. "VLC media player").
+	Identity string
+	// DesktopEntry is the basename of the .desktop file of the media player without the ".desktop" extension. The
+	// property is not provided if it is empty.
+	DesktopEntry string
+	// SupportedURISchemes contains the URI schemes supported by the media player (e.g. "file").
+	SupportedURISchemes []string
+	// SupportedMimeTypes contains the mime-types supported by the media player (e.g. "audio/mpeg").
+	SupportedMimeTypes []string
+}
+
+// ServerState contains the properties of a media player exported by Server. Use Server.Update to change them.
+// PlayerState.LoopStatus and PlayerState.Shuffle are not provided if they are empty (the media player does not support
+// looping or shuffling). PlayerState.Position is ignored, the position is read via PlayerHandler.Position as it
+// changes continuously.
+// Empty values of required properties are replaced by valid ones: PlaybackStatusStopped for PlayerState.PlaybackStatus
+// and 1.0 for PlayerState.Rate, PlayerState.MinimumRate and PlayerState.MaximumRate.
+type ServerState struct {
+	MediaPlayerState
+	PlayerState
+}
+
+// clone returns a deep copy of the state which does not share slices, maps or pointers.
+func (s ServerState) clone() ServerState {
+	s.SupportedURISchemes = slices.Clone(s.SupportedURISchemes)
+	s.SupportedMimeTypes = slices.Clone(s.SupportedMimeTypes)
+	s.Metadata = maps.Clone(s.Metadata)
+	if s.Shuffle != nil {
+		shuffle := *s.Shuffle
+		s.Shuffle = &shuffle
+	}
+
+	return s
+}
+
+func (s *ServerState) normalize() {
+	if s.PlaybackStatus == "" {
+		s.PlaybackStatus = PlaybackStatusStopped
+	}
+	for _, rate := range []*float64{&s.Rate, &s.MinimumRate, &s.MaximumRate} {
+		if *rate == 0 {
+			*rate = 1
+		}
+	}
+}
+
+// serverProperty is a property of an exported interface. get returns nil if the property is not provided, set is nil
+// if the property is read-only. Changes of volatile properties are not emitted.
+type serverProperty struct {
+	get      func(state *ServerState) interface{}
+	set      func(v dbus.Variant) error
+	volatile bool
+}
+
+func (s *Server) playerProperties() map[string]serverProperty {
+	h := s.handler
+	return map[string]serverProperty{
+		rootCanQuitProperty:  {get: func(st *ServerState) interface{} { return st.CanQuit }},
+		rootCanRaiseProperty: {get: func(st *ServerState) interface{} { return st.CanRaise }},
+		rootFullscreenProperty: {
+			get: func(st *ServerState) interface{} { return st.Fullscreen },
+			set: serverSetter(rootFullscreenProperty, decodeValue[bool], func(fullscreen bool) error {
+				if !s.State().CanSetFullscreen {
+					return nil
+				}
+				return handleSet(s, h.SetFullscreen, fullscreen, func(st *ServerState) { st.Fullscreen = fullscreen })
+			}),
+		},
+		rootCanSetFullscreenProperty:    {get: func(st *ServerState) interface{} { return st.CanSetFullscreen }},
+		rootHasTrackListProperty:        {get: func(*ServerState) interface{} { return s.trackList != nil }},
+		rootIdentityProperty:            {get: func(st *ServerState) interface{} { return st.Identity }},
+		rootDesktopEntryProperty:        {get: func(st *ServerState) interface{} { return omitEmpty(st.DesktopEntry) }},
+		rootSupportedURISchemesProperty: {get: func(st *ServerState) interface{} { return nonNil(st.SupportedURISchemes) }},
+		rootSupportedMimeTypesProperty:  {get: func(st *ServerState) interface{} { return nonNil(st.SupportedMimeTypes) }},
+
+		playerPlaybackStatusProperty: {get: func(st *ServerState) interface{} { return string(st.PlaybackStatus) }},
+		playerLoopStatusProperty: {
+			get: func(st *ServerState) interface{} { return omitEmpty(string(st.LoopStatus)) },
+			set: serverSetter(playerLoopStatusProperty, decodeValue[string], func(status string) error {
+				switch LoopStatus(status) {
+				case LoopStatusNone, LoopStatusTrack, LoopStatusPlaylist:
+				default:
+					return dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{fmt.Sprintf("invalid loop status %q", status)})
+				}
+				if !s.State().CanControl {
+					return nil
+				}
+				return handleSet(s, h.SetLoopStatus, LoopStatus(status), func(st *ServerState) { st.LoopStatus = LoopStatus(status) })
+			}),
+		},
+		playerRateProperty: {
+			get: func(st *ServerState) interface{} { return st.Rate },
+			set: serverSetter(playerRateProperty, decodeFloat64, func(rate float64) error {
+				state := s.State()
+				if !state.CanControl {
+					return nil
+				}
+				if rate == 0 { // see: https://specifications.freedesktop.org/mpris-spec/2.2/Player_Interface.html#Property:Rate
+					if !state.CanPause {
+						return nil
+					}
+					return h.Pause()
+				}
+				if rate < state.MinimumRate || rate > state.MaximumRate {
+					return dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{fmt.Sprintf("rate %g is out of range [%g, %g]", rate, state.MinimumRate, state.MaximumRate)})
+				}
+				return handleSet(s, h.SetRate, rate, func(st *ServerState) { st.Rate = rate })
+			}),
+		},
+		playerShuffleProperty: {
+			get: func(st *ServerState) interface{} {
+				if st.Shuffle != nil {
+					return *st.Shuffle
+				}
+				return nil
+			},
+			set: serverSetter(playerShuffleProperty, decodeValue[bool], func(shuffle bool) error {
+				if !s.State().CanControl {
+					return nil
+				}
+				return handleSet(s, h.SetShuffle, shuffle, func(st *ServerState) { st.Shuffle = &shuffle })
+			}),
+		},
+		playerMetadataProperty: {get: func(st *ServerState) interface{} {
+			md := map[string]dbus.Variant(st.Metadata)
+			if md == nil {
+				md = map[string]dbus.Variant{}
+			}
+			return md
+		}},
+		playerVolumeProperty: {
+			get: func(st *ServerState) interface{} { return st.Volume },
+			set: serverSetter(playerVolumeProperty, decodeFloat64, func(volume float64) error {
+				if !s.State().CanControl {
+					return nil
+				}
+				volume = max(volume, 0)
+				return handleSet(s, h.SetVolume, volume, func(st *ServerState) { st.Volume = volume })
+			}),
+		},
+		playerPositionProperty: {
+			get:      func(*ServerState) interface{} { return h.Position().Microseconds() },
+			volatile: true,
+		},
+		playerMinimumRateProperty:   {get: func(st *ServerState) interface{} { return st.MinimumRate }},
+		playerMaximumRateProperty:   {get: func(st *ServerState) interface{} { return st.MaximumRate }},
+		playerCanGoNextProperty:     {get: func(st *ServerState) interface{} { return st.CanGoNext }},
+		playerCanGoPreviousProperty: {get: func(st *ServerState) interface{} { return st.CanGoPrevious }},
+		playerCanPlayProperty:       {get: func(st *ServerState) interface{} { return st.CanPlay }},
+		playerCanPauseProperty:      {get: func(st *ServerState) interface{} { return st.CanPause }},
+		playerCanSeekProperty:       {get: func(st *ServerState) interface{} { return st.CanSeek }},
+		playerCanControlProperty:    {get: func(st *ServerState) interface{} { return st.CanControl }},
+	}
+}
+
+// handleSet calls the setter of the handler and updates the state via update if it succeeded.
+func handleSet[T any](s *Server, set func(T) error, value T, update func(state *ServerState)) error {
+	err := set(value)
+	if err != nil {
+		return err
+	}
+
+	return s.Update(update)
+}
+
+// serverSetter returns a setter of a serverProperty which decodes the value via decode before calling set.
+func serverSetter[T any](property string, decode func(string, dbus.Variant) (T, error), set func(T) error) func(dbus.Variant) error {
+	return func(v dbus.Variant) error {
+		t, err := decode(property, v)
+		if err != nil {
+			return dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{err.Error()})
+		}
+		return set(t)
+	}
+}
+
+// diff returns the properties of the given interface which differ between old and new. Properties which are provided
+// by old but not by new are returned as invalidated.
+func (s *Server) diff(iface string, old, new *ServerState) (map[string]dbus.Variant, []string) {
+	changed := map[string]dbus.Variant{}
+	invalidated := []string{}
+	for key, property := range s.properties {
+		propertyIface, name, _ := splitProperty(key)
+		if propertyIface != iface || property.volatile {
+			continue
+		}
+
+		oldValue, newValue := property.get(old), property.get(new)
+		switch {
+		case reflect.DeepEqual(oldValue, newValue):
+		case newValue == nil:
+			invalidated = append(invalidated, name)
+		default:
+			changed[name] = dbus.MakeVariant(newValue)
+		}
+	}
+	sort.Strings(invalidated)
+
+	return changed, invalidated
+}
+
+// omitEmpty returns nil for empty strings to omit the corresponding optional property.
+func omitEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nonNil returns an empty slice for nil to not send an invalid dbus value.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
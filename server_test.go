@@ -0,0 +1,832 @@
+package mpris
+
+import (
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHandler is a PlayerHandler which records all calls of methods and setters.
+type testHandler struct {
+	mu    sync.Mutex
+	calls []string
+	args  []interface{}
+	err   error
+}
+
+func (h *testHandler) record(method string, args ...interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, method)
+	h.args = append(h.args, args...)
+	return h.err
+}
+
+func (h *testHandler) recorded() ([]string, []interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls, h.args
+}
+
+func (h *testHandler) Raise() error {
+	return h.record("Raise")
+}
+
+func (h *testHandler) Quit() error {
+	return h.record("Quit")
+}
+
+func (h *testHandler) SetFullscreen(fullscreen bool) error {
+	return h.record("SetFullscreen", fullscreen)
+}
+
+func (h *testHandler) Next() error {
+	return h.record("Next")
+}
+
+func (h *testHandler) Previous() error {
+	return h.record("Previous")
+}
+
+func (h *testHandler) Pause() error {
+	return h.record("Pause")
+}
+
+func (h *testHandler) PlayPause() error {
+	return h.record("PlayPause")
+}
+
+func (h *testHandler) Stop() error {
+	return h.record("Stop")
+}
+
+func (h *testHandler) Play() error {
+	return h.record("Play")
+}
+
+func (h *testHandler) Seek(offset time.Duration) error {
+	return h.record("Seek", offset)
+}
+
+func (h *testHandler) SetPosition(trackID dbus.ObjectPath, position time.Duration) error {
+	return h.record("SetPosition", trackID, position)
+}
+
+func (h *testHandler) OpenURI(uri string) error {
+	return h.record("OpenURI", uri)
+}
+
+func (h *testHandler) SetLoopStatus(status LoopStatus) error {
+	return h.record("SetLoopStatus", status)
+}
+
+func (h *testHandler) SetRate(rate float64) error {
+	return h.record("SetRate", rate)
+}
+
+func (h *testHandler) SetShuffle(shuffle bool) error {
+	return h.record("SetShuffle", shuffle)
+}
+
+func (h *testHandler) SetVolume(volume float64) error {
+	return h.record("SetVolume", volume)
+}
+
+func (h *testHandler) Position() time.Duration {
+	return 1500 * time.Millisecond
+}
+
+// newTestServerState returns a state with all capabilities enabled unless canControl is false.
+func newTestServerState(canControl bool) ServerState {
+	return ServerState{
+		MediaPlayerState: MediaPlayerState{
+			CanQuit:             canControl,
+			CanSetFullscreen:    canControl,
+			CanRaise:            canControl,
+			Identity:            "Test Player",
+			SupportedURISchemes: []string{"file"},
+		},
+		PlayerState: PlayerState{
+			PlaybackStatus: PlaybackStatusPlaying,
+			LoopStatus:     LoopStatusNone,
+			Rate:           1,
+			Metadata: Metadata{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+			Volume:        0.5,
+			MinimumRate:   0.5,
+			MaximumRate:   2,
+			CanGoNext:     canControl,
+			CanGoPrevious: canControl,
+			CanPlay:       canControl,
+			CanPause:      canControl,
+			CanSeek:       canControl,
+			CanControl:    canControl,
+		},
+	}
+}
+
+// newTestServer returns a Server which has not been exported.
+func newTestServer(handler PlayerHandler, state ServerState, connection dbusServerConn) *Server {
+	s := &Server{
+		busName:    "org.mpris.MediaPlayer2.test",
+		handler:    handler,
+		connection: connection,
+		state:      state,
+	}
+	s.properties = s.playerProperties()
+	if trackListHandler, ok := handler.(TrackListHandler); ok {
+		s.trackList = newServerTrackList(trackListHandler, connection)
+		maps.Copy(s.properties, s.trackListProperties())
+	}
+	if playlistsHandler, ok := handler.(PlaylistsHandler); ok {
+		s.playlists = newServerPlaylists(playlistsHandler, connection)
+		maps.Copy(s.properties, s.playlistsProperties())
+	}
+	return s
+}
+
+// emitted is a signal emitted via dbusServerConnMock.
+type emitted struct {
+	name   string
+	values []interface{}
+}
+
+// newEmitRecorder returns a dbusServerConnMock which records all emitted signals.
+func newEmitRecorder(emitErr error) (*dbusServerConnMock, *[]emitted) {
+	var signals []emitted
+	return &dbusServerConnMock{
+		EmitFunc: func(path dbus.ObjectPath, name string, values ...interface{}) error {
+			if path != "/org/mpris/MediaPlayer2" {
+				panic("unexpected path " + path)
+			}
+			signals = append(signals, emitted{name: name, values: values})
+			return emitErr
+		},
+	}, &signals
+}
+
+func TestNewServer(t *testing.T) {
+	type export struct {
+		iface   string
+		path    dbus.ObjectPath
+		mapping map[string]string
+	}
+
+	tests := []struct {
+		name            string
+		exportErr       error
+		failingExport   string
+		unexportErr     error
+		requestReply    dbus.RequestNameReply
+		requestErr      error
+		expectedExports []export
+		expectedUnexps  []string
+		expectedName    string
+		expectedErr     string
+		expectedErrIs   error
+	}{
+		{
+			name:         "exported",
+			requestReply: dbus.RequestNameReplyPrimaryOwner,
+			expectedExports: []export{
+				{iface: "org.freedesktop.DBus.Introspectable", path: "/org/mpris/MediaPlayer2"},
+				{iface: "org.freedesktop.DBus.Properties", path: "/org/mpris/MediaPlayer2"},
+				{iface: "org.mpris.MediaPlayer2", path: "/org/mpris/MediaPlayer2"},
+				{iface: "org.mpris.MediaPlayer2.Player", path: "/org/mpris/MediaPlayer2", mapping: map[string]string{"SeekOffset": "Seek", "OpenURI": "OpenUri"}},
+			},
+			expectedName: "org.mpris.MediaPlayer2.test",
+		}, {
+			name:            "export error",
+			exportErr:       errors.New("nope"),
+			expectedExports: []export{{iface: "org.freedesktop.DBus.Introspectable", path: "/org/mpris/MediaPlayer2"}},
+			expectedErr:     "failed to export \"org.freedesktop.DBus.Introspectable\": nope",
+		}, {
+			name:            "export error after exports",
+			exportErr:       errors.New("nope"),
+			failingExport:   "org.mpris.MediaPlayer2",
+			expectedExports: make([]export, 3),
+			expectedUnexps:  []string{"org.freedesktop.DBus.Introspectable", "org.freedesktop.DBus.Properties"},
+			expectedErr:     "failed to export \"org.mpris.MediaPlayer2\": nope",
+		}, {
+			name:            "request name error",
+			requestErr:      errors.New("nope"),
+			expectedExports: make([]export, 4),
+			expectedUnexps:  []string{"org.freedesktop.DBus.Introspectable", "org.freedesktop.DBus.Properties", "org.mpris.MediaPlayer2", "org.mpris.MediaPlayer2.Player"},
+			expectedName:    "org.mpris.MediaPlayer2.test",
+			expectedErr:     "failed to request name \"org.mpris.MediaPlayer2.test\": nope",
+		}, {
+			name:            "name taken",
+			requestReply:    dbus.RequestNameReplyExists,
+			expectedExports: make([]export, 4),
+			expectedUnexps:  []string{"org.freedesktop.DBus.Introspectable", "org.freedesktop.DBus.Properties", "org.mpris.MediaPlayer2", "org.mpris.MediaPlayer2.Player"},
+			expectedName:    "org.mpris.MediaPlayer2.test",
+			expectedErr:     "failed to request name \"org.mpris.MediaPlayer2.test\": the bus name is already taken",
+			expectedErrIs:   ErrNameTaken,
+		}, {
+			name:            "unexport error",
+			unexportErr:     errors.New("nope"),
+			requestReply:    dbus.RequestNameReplyExists,
+			expectedExports: make([]export, 4),
+			expectedUnexps:  []string{"org.freedesktop.DBus.Introspectable", "org.freedesktop.DBus.Properties", "org.mpris.MediaPlayer2", "org.mpris.MediaPlayer2.Player"},
+			expectedName:    "org.mpris.MediaPlayer2.test",
+			expectedErr: "failed to request name \"org.mpris.MediaPlayer2.test\": the bus name is already taken\n" +
+				"failed to unexport \"org.freedesktop.DBus.Introspectable\": nope\n" +
+				"failed to unexport \"org.freedesktop.DBus.Properties\": nope\n" +
+				"failed to unexport \"org.mpris.MediaPlayer2\": nope\n" +
+				"failed to unexport \"org.mpris.MediaPlayer2.Player\": nope",
+			expectedErrIs: ErrNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var exports []export
+			var unexports []string
+			var requestedName string
+			var requestedFlags dbus.RequestNameFlags
+			connMock := &dbusServerConnMock{
+				ExportWithMapFunc: func(v interface{}, mapping map[string]string, path dbus.ObjectPath, iface string) error {
+					if v == nil {
+						unexports = append(unexports, iface)
+						return tt.unexportErr
+					}
+					exports = append(exports, export{iface: iface, path: path, mapping: mapping})
+					if tt.failingExport != "" && tt.failingExport != iface {
+						return nil
+					}
+					return tt.exportErr
+				},
+				RequestNameFunc: func(name string, flags dbus.RequestNameFlags) (dbus.RequestNameReply, error) {
+					requestedName, requestedFlags = name, flags
+					return tt.requestReply, tt.requestErr
+				},
+			}
+
+			s, err := newServer("test", &testHandler{}, ServerState{}, connMock)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
+				assert.Len(t, exports, len(tt.expectedExports))
+				assert.Equal(t, tt.expectedUnexps, unexports)
+				assert.Equal(t, tt.expectedName, requestedName)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExports, exports)
+			assert.Equal(t, tt.expectedName, requestedName)
+			assert.Equal(t, dbus.NameFlagDoNotQueue, requestedFlags)
+			assert.Equal(t, tt.expectedName, s.BusName())
+			assert.Equal(t, PlaybackStatusStopped, s.State().PlaybackStatus)
+			assert.Equal(t, 1.0, s.State().Rate)
+		})
+	}
+}
+
+func TestServer_Close(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseErr  error
+		closeErr    error
+		expectedErr string
+	}{
+		{
+			name: "closed",
+		}, {
+			name:        "release error",
+			releaseErr:  errors.New("nope"),
+			expectedErr: "failed to release name \"org.mpris.MediaPlayer2.test\": nope",
+		}, {
+			name:        "close error",
+			closeErr:    errors.New("nope"),
+			expectedErr: "failed to close dbus connection: nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var releasedName string
+			connMock := &dbusServerConnMock{
+				ReleaseNameFunc: func(name string) (dbus.ReleaseNameReply, error) {
+					releasedName = name
+					return dbus.ReleaseNameReplyReleased, tt.releaseErr
+				},
+				CloseFunc: func() error {
+					return tt.closeErr
+				},
+			}
+			s := &Server{busName: "org.mpris.MediaPlayer2.test", connection: connMock}
+
+			err := s.Close()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "org.mpris.MediaPlayer2.test", releasedName)
+		})
+	}
+}
+
+func TestServer_Methods(t *testing.T) {
+	tests := []struct {
+		name           string
+		disabled       bool
+		handlerErr     error
+		action         func(r serverRoot, p serverPlayer) *dbus.Error
+		expectedCalls  []string
+		expectedArgs   []interface{}
+		expectedErr    string
+		expectedErrMsg string
+	}{
+		{
+			name:          "Raise",
+			action:        func(r serverRoot, _ serverPlayer) *dbus.Error { return r.Raise() },
+			expectedCalls: []string{"Raise"},
+		}, {
+			name:     "Raise not supported",
+			disabled: true,
+			action:   func(r serverRoot, _ serverPlayer) *dbus.Error { return r.Raise() },
+		}, {
+			name:          "Quit",
+			action:        func(r serverRoot, _ serverPlayer) *dbus.Error { return r.Quit() },
+			expectedCalls: []string{"Quit"},
+		}, {
+			name:     "Quit not supported",
+			disabled: true,
+			action:   func(r serverRoot, _ serverPlayer) *dbus.Error { return r.Quit() },
+		}, {
+			name:          "Next",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Next() },
+			expectedCalls: []string{"Next"},
+		}, {
+			name:     "Next not supported",
+			disabled: true,
+			action:   func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Next() },
+		}, {
+			name:          "Previous",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Previous() },
+			expectedCalls: []string{"Previous"},
+		}, {
+			name:          "Pause",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Pause() },
+			expectedCalls: []string{"Pause"},
+		}, {
+			name:          "PlayPause",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.PlayPause() },
+			expectedCalls: []string{"PlayPause"},
+		}, {
+			name:          "Stop",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Stop() },
+			expectedCalls: []string{"Stop"},
+		}, {
+			name:     "Stop not supported",
+			disabled: true,
+			action:   func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Stop() },
+		}, {
+			name:          "Play",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Play() },
+			expectedCalls: []string{"Play"},
+		}, {
+			name:          "Seek",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SeekOffset(-1500000) },
+			expectedCalls: []string{"Seek"},
+			expectedArgs:  []interface{}{-1500 * time.Millisecond},
+		}, {
+			name:     "Seek not supported",
+			disabled: true,
+			action:   func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SeekOffset(-1500000) },
+		}, {
+			name:          "SetPosition",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SetPosition("/track/1", 60000000) },
+			expectedCalls: []string{"SetPosition"},
+			expectedArgs:  []interface{}{dbus.ObjectPath("/track/1"), time.Minute},
+		}, {
+			name:   "SetPosition of other track",
+			action: func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SetPosition("/track/2", 0) },
+		}, {
+			name:   "SetPosition beyond end of track",
+			action: func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SetPosition("/track/1", 60000001) },
+		}, {
+			name:   "SetPosition before start of track",
+			action: func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SetPosition("/track/1", -1) },
+		}, {
+			name:     "SetPosition not supported",
+			disabled: true,
+			action:   func(_ serverRoot, p serverPlayer) *dbus.Error { return p.SetPosition("/track/1", 0) },
+		}, {
+			name:          "OpenUri",
+			action:        func(_ serverRoot, p serverPlayer) *dbus.Error { return p.OpenURI("file:///music.mp3") },
+			expectedCalls: []string{"OpenURI"},
+			expectedArgs:  []interface{}{"file:///music.mp3"},
+		}, {
+			name:           "handler error",
+			handlerErr:     errors.New("nope"),
+			action:         func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Play() },
+			expectedCalls:  []string{"Play"},
+			expectedErr:    "org.freedesktop.DBus.Error.Failed",
+			expectedErrMsg: "nope",
+		}, {
+			name:           "handler dbus error",
+			handlerErr:     dbus.NewError("org.mpris.MediaPlayer2.test.Error", []interface{}{"nope"}),
+			action:         func(_ serverRoot, p serverPlayer) *dbus.Error { return p.Play() },
+			expectedCalls:  []string{"Play"},
+			expectedErr:    "org.mpris.MediaPlayer2.test.Error",
+			expectedErrMsg: "nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &testHandler{err: tt.handlerErr}
+			s := newTestServer(h, newTestServerState(!tt.disabled), nil)
+
+			err := tt.action(serverRoot{server: s}, serverPlayer{server: s})
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+				assert.Equal(t, tt.expectedErrMsg, err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, h.calls)
+			assert.Equal(t, tt.expectedArgs, h.args)
+		})
+	}
+}
+
+func TestServerProperties_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		iface         string
+		property      string
+		expectedValue interface{}
+		expectedErr   string
+	}{
+		{
+			name:          "Identity",
+			iface:         "org.mpris.MediaPlayer2",
+			property:      "Identity",
+			expectedValue: "Test Player",
+		}, {
+			name:          "HasTrackList",
+			iface:         "org.mpris.MediaPlayer2",
+			property:      "HasTrackList",
+			expectedValue: false,
+		}, {
+			name:          "SupportedMimeTypes nil",
+			iface:         "org.mpris.MediaPlayer2",
+			property:      "SupportedMimeTypes",
+			expectedValue: []string{},
+		}, {
+			name:        "DesktopEntry empty",
+			iface:       "org.mpris.MediaPlayer2",
+			property:    "DesktopEntry",
+			expectedErr: "org.freedesktop.DBus.Error.UnknownProperty",
+		}, {
+			name:          "PlaybackStatus",
+			iface:         "org.mpris.MediaPlayer2.Player",
+			property:      "PlaybackStatus",
+			expectedValue: "Playing",
+		}, {
+			name:          "Metadata",
+			iface:         "org.mpris.MediaPlayer2.Player",
+			property:      "Metadata",
+			expectedValue: map[string]dbus.Variant(newTestServerState(true).Metadata),
+		}, {
+			name:          "Position",
+			iface:         "org.mpris.MediaPlayer2.Player",
+			property:      "Position",
+			expectedValue: int64(1500000),
+		}, {
+			name:        "Shuffle not supported",
+			iface:       "org.mpris.MediaPlayer2.Player",
+			property:    "Shuffle",
+			expectedErr: "org.freedesktop.DBus.Error.UnknownProperty",
+		}, {
+			name:        "unknown property",
+			iface:       "org.mpris.MediaPlayer2.Player",
+			property:    "Unknown",
+			expectedErr: "org.freedesktop.DBus.Error.UnknownProperty",
+		}, {
+			name:        "unknown interface",
+			iface:       "org.mpris.MediaPlayer2.Unknown",
+			property:    "Identity",
+			expectedErr: "org.freedesktop.DBus.Error.UnknownInterface",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(&testHandler{}, newTestServerState(true), nil)
+
+			v, err := serverProperties{server: s}.Get(tt.iface, tt.property)
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, dbus.MakeVariant(tt.expectedValue), v)
+		})
+	}
+}
+
+func TestServerProperties_GetAll(t *testing.T) {
+	state := newTestServerState(true)
+	shuffle := true
+	state.Shuffle = &shuffle
+	s := newTestServer(&testHandler{}, state, nil)
+
+	values, err := serverProperties{server: s}.GetAll("org.mpris.MediaPlayer2")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]dbus.Variant{
+		"CanQuit":             dbus.MakeVariant(true),
+		"CanRaise":            dbus.MakeVariant(true),
+		"Fullscreen":          dbus.MakeVariant(false),
+		"CanSetFullscreen":    dbus.MakeVariant(true),
+		"HasTrackList":        dbus.MakeVariant(false),
+		"Identity":            dbus.MakeVariant("Test Player"),
+		"SupportedUriSchemes": dbus.MakeVariant([]string{"file"}),
+		"SupportedMimeTypes":  dbus.MakeVariant([]string{}),
+	}, values)
+
+	values, err = serverProperties{server: s}.GetAll("org.mpris.MediaPlayer2.Player")
+	require.Nil(t, err)
+	assert.Len(t, values, 15)
+	assert.Equal(t, dbus.MakeVariant(true), values["Shuffle"])
+
+	_, err = serverProperties{server: s}.GetAll("org.mpris.MediaPlayer2.Unknown")
+	require.NotNil(t, err)
+	assert.Equal(t, "org.freedesktop.DBus.Error.UnknownInterface", err.Name)
+}
+
+func TestServerProperties_Set(t *testing.T) {
+	tests := []struct {
+		name            string
+		disabled        bool
+		handlerErr      error
+		iface           string
+		property        string
+		value           interface{}
+		expectedCalls   []string
+		expectedArgs    []interface{}
+		expectedChanged map[string]dbus.Variant
+		expectedErr     string
+	}{
+		{
+			name:            "Fullscreen",
+			iface:           "org.mpris.MediaPlayer2",
+			property:        "Fullscreen",
+			value:           true,
+			expectedCalls:   []string{"SetFullscreen"},
+			expectedArgs:    []interface{}{true},
+			expectedChanged: map[string]dbus.Variant{"Fullscreen": dbus.MakeVariant(true)},
+		}, {
+			name:            "LoopStatus",
+			property:        "LoopStatus",
+			value:           "Track",
+			expectedCalls:   []string{"SetLoopStatus"},
+			expectedArgs:    []interface{}{LoopStatusTrack},
+			expectedChanged: map[string]dbus.Variant{"LoopStatus": dbus.MakeVariant("Track")},
+		}, {
+			name:          "LoopStatus unchanged",
+			property:      "LoopStatus",
+			value:         "None",
+			expectedCalls: []string{"SetLoopStatus"},
+			expectedArgs:  []interface{}{LoopStatusNone},
+		}, {
+			name:        "LoopStatus invalid",
+			property:    "LoopStatus",
+			value:       "Forever",
+			expectedErr: "org.freedesktop.DBus.Error.InvalidArgs",
+		}, {
+			name:        "LoopStatus wrong type",
+			property:    "LoopStatus",
+			value:       int32(1),
+			expectedErr: "org.freedesktop.DBus.Error.InvalidArgs",
+		}, {
+			name:     "LoopStatus not supported",
+			disabled: true,
+			property: "LoopStatus",
+			value:    "Track",
+		}, {
+			name:            "Rate",
+			property:        "Rate",
+			value:           1.5,
+			expectedCalls:   []string{"SetRate"},
+			expectedArgs:    []interface{}{1.5},
+			expectedChanged: map[string]dbus.Variant{"Rate": dbus.MakeVariant(1.5)},
+		}, {
+			name:          "Rate 0 pauses",
+			property:      "Rate",
+			value:         0.0,
+			expectedCalls: []string{"Pause"},
+		}, {
+			name:        "Rate out of range",
+			property:    "Rate",
+			value:       4.0,
+			expectedErr: "org.freedesktop.DBus.Error.InvalidArgs",
+		}, {
+			name:            "Shuffle",
+			property:        "Shuffle",
+			value:           true,
+			expectedCalls:   []string{"SetShuffle"},
+			expectedArgs:    []interface{}{true},
+			expectedChanged: map[string]dbus.Variant{"Shuffle": dbus.MakeVariant(true)},
+		}, {
+			name:            "Volume",
+			property:        "Volume",
+			value:           0.75,
+			expectedCalls:   []string{"SetVolume"},
+			expectedArgs:    []interface{}{0.75},
+			expectedChanged: map[string]dbus.Variant{"Volume": dbus.MakeVariant(0.75)},
+		}, {
+			name:            "Volume negative",
+			property:        "Volume",
+			value:           int32(-1),
+			expectedCalls:   []string{"SetVolume"},
+			expectedArgs:    []interface{}{0.0},
+			expectedChanged: map[string]dbus.Variant{"Volume": dbus.MakeVariant(0.0)},
+		}, {
+			name:     "Volume not supported",
+			disabled: true,
+			property: "Volume",
+			value:    0.75,
+		}, {
+			name:          "handler error",
+			handlerErr:    errors.New("nope"),
+			property:      "Volume",
+			value:         0.75,
+			expectedCalls: []string{"SetVolume"},
+			expectedArgs:  []interface{}{0.75},
+			expectedErr:   "org.freedesktop.DBus.Error.Failed",
+		}, {
+			name:        "read-only",
+			property:    "PlaybackStatus",
+			value:       "Paused",
+			expectedErr: "org.freedesktop.DBus.Error.PropertyReadOnly",
+		}, {
+			name:        "unknown property",
+			property:    "Unknown",
+			value:       "Paused",
+			expectedErr: "org.freedesktop.DBus.Error.UnknownProperty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iface := "org.mpris.MediaPlayer2.Player"
+			if tt.iface != "" {
+				iface = tt.iface
+			}
+			h := &testHandler{err: tt.handlerErr}
+			connMock, signals := newEmitRecorder(nil)
+			s := newTestServer(h, newTestServerState(!tt.disabled), connMock)
+
+			err := serverProperties{server: s}.Set(iface, tt.property, dbus.MakeVariant(tt.value))
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, h.calls)
+			assert.Equal(t, tt.expectedArgs, h.args)
+
+			if tt.expectedChanged == nil {
+				assert.Empty(t, *signals)
+				return
+			}
+			assert.Equal(t, []emitted{{
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{iface, tt.expectedChanged, []string{}},
+			}}, *signals)
+		})
+	}
+}
+
+func TestServer_Update(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          func(state *ServerState)
+		emitErr         error
+		expectedSignals []emitted
+		expectedErr     string
+	}{
+		{
+			name:   "nothing changed",
+			update: func(state *ServerState) {},
+		}, {
+			name: "coalesced",
+			update: func(state *ServerState) {
+				state.PlaybackStatus = PlaybackStatusPaused
+				state.Metadata["xesam:title"] = dbus.MakeVariant("Title")
+				state.CanGoNext = false
+				state.Position = time.Minute
+			},
+			expectedSignals: []emitted{{
+				name: "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{
+					"PlaybackStatus": dbus.MakeVariant("Paused"),
+					"Metadata": dbus.MakeVariant(map[string]dbus.Variant{
+						"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+						"mpris:length":  dbus.MakeVariant(int64(60000000)),
+						"xesam:title":   dbus.MakeVariant("Title"),
+					}),
+					"CanGoNext": dbus.MakeVariant(false),
+				}, []string{}},
+			}},
+		}, {
+			name: "both interfaces",
+			update: func(state *ServerState) {
+				state.Identity = "Other Player"
+				state.Volume = 1
+			},
+			expectedSignals: []emitted{{
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2", map[string]dbus.Variant{"Identity": dbus.MakeVariant("Other Player")}, []string{}},
+			}, {
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{"Volume": dbus.MakeVariant(1.0)}, []string{}},
+			}},
+		}, {
+			name: "invalidated",
+			update: func(state *ServerState) {
+				state.LoopStatus = ""
+				state.Rate = 0
+			},
+			expectedSignals: []emitted{{
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{}, []string{"LoopStatus"}},
+			}},
+		}, {
+			name: "emit error",
+			update: func(state *ServerState) {
+				state.Volume = 1
+			},
+			emitErr: errors.New("nope"),
+			expectedSignals: []emitted{{
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{"Volume": dbus.MakeVariant(1.0)}, []string{}},
+			}},
+			expectedErr: "failed to emit \"PropertiesChanged\" of \"org.mpris.MediaPlayer2.Player\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connMock, signals := newEmitRecorder(tt.emitErr)
+			initial := newTestServerState(true)
+			s := newTestServer(&testHandler{}, initial.clone(), connMock)
+
+			err := s.Update(tt.update)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedSignals, *signals)
+
+			expected := initial.clone()
+			tt.update(&expected)
+			expected.normalize()
+			assert.Equal(t, expected, s.State())
+		})
+	}
+}
+
+func TestServer_State(t *testing.T) {
+	s := newTestServer(&testHandler{}, newTestServerState(true), nil)
+
+	state := s.State()
+	state.Metadata["xesam:title"] = dbus.MakeVariant("Title")
+	state.SupportedURISchemes[0] = "http"
+
+	assert.Equal(t, newTestServerState(true), s.State())
+}
+
+func TestServer_EmitSeeked(t *testing.T) {
+	connMock, signals := newEmitRecorder(nil)
+	s := newTestServer(&testHandler{}, newTestServerState(true), connMock)
+
+	err := s.EmitSeeked(90 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []emitted{{name: "org.mpris.MediaPlayer2.Player.Seeked", values: []interface{}{int64(90000000)}}}, *signals)
+
+	connMock, _ = newEmitRecorder(errors.New("nope"))
+	s = newTestServer(&testHandler{}, newTestServerState(true), connMock)
+	err = s.EmitSeeked(90 * time.Second)
+	assert.EqualError(t, err, "failed to emit \"Seeked\": nope")
+}
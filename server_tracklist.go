@@ -0,0 +1,295 @@
+package mpris
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// TrackListHandler can be implemented by a PlayerHandler additionally to export the interface
+// org.mpris.MediaPlayer2.TrackList. The tracks are managed via Server.TrackList. HasTrackList is true if the handler
+// implements TrackListHandler.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html
+type TrackListHandler interface {
+	// AddTrack adds the track with the given uri after afterTrack (NoTrack adds it at the start). It is only called
+	// when CanEditTracks is true and afterTrack is part of the tracklist. Use ServerTrackList.Add to add the track
+	// once it has been loaded.
+	AddTrack(uri string, afterTrack dbus.ObjectPath, setAsCurrent bool) error
+	// RemoveTrack removes the given track. It is only called when CanEditTracks is true and the track is part of the
+	// tracklist. The track is removed from the tracklist when nil is returned.
+	RemoveTrack(trackID dbus.ObjectPath) error
+	// GoTo skips to the given track. It is only called when the track is part of the tracklist.
+	GoTo(trackID dbus.ObjectPath) error
+}
+
+// ServerTrackList is the ordered list of tracks of a media player exported by Server. It is safe for concurrent use.
+// All changes are announced via the signals of org.mpris.MediaPlayer2.TrackList and the invalidation of its Tracks
+// property.
+// Use Server.TrackList to get the ServerTrackList of a Server.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html
+type ServerTrackList struct {
+	handler    TrackListHandler
+	connection dbusServerConn
+
+	mu            sync.Mutex
+	trackIDs      []dbus.ObjectPath
+	metadata      map[dbus.ObjectPath]Metadata
+	canEditTracks bool
+}
+
+func newServerTrackList(handler TrackListHandler, connection dbusServerConn) *ServerTrackList {
+	return &ServerTrackList{
+		handler:    handler,
+		connection: connection,
+		metadata:   map[dbus.ObjectPath]Metadata{},
+	}
+}
+
+// Tracks returns the ids of all tracks in order.
+func (t *ServerTrackList) Tracks() []dbus.ObjectPath {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.trackIDs)
+}
+
+// Metadata returns a copy of the metadata of the given track and whether it is part of the tracklist.
+func (t *ServerTrackList) Metadata(trackID dbus.ObjectPath) (Metadata, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	md, ok := t.metadata[trackID]
+	return maps.Clone(md), ok
+}
+
+// CanEditTracks returns whether clients may add and remove tracks.
+func (t *ServerTrackList) CanEditTracks() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.canEditTracks
+}
+
+// SetCanEditTracks sets whether clients may add and remove tracks and emits PropertiesChanged if it changed.
+func (t *ServerTrackList) SetCanEditTracks(canEditTracks bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.canEditTracks == canEditTracks {
+		return nil
+	}
+	t.canEditTracks = canEditTracks
+
+	return emitPropertiesChanged(t.connection, trackListInterface, map[string]dbus.Variant{"CanEditTracks": dbus.MakeVariant(canEditTracks)}, []string{})
+}
+
+// Replace replaces all tracks and emits TrackListReplaced. Every track needs a unique mpris:trackid, otherwise an
+// error wrapping ErrInvalidMetadata is returned. currentTrack is the id of the current track (NoTrack if there is none).
+// The metadata is copied, so it may be modified afterwards.
+func (t *ServerTrackList) Replace(tracks []Metadata, currentTrack dbus.ObjectPath) error {
+	trackIDs := make([]dbus.ObjectPath, len(tracks))
+	metadata := make(map[dbus.ObjectPath]Metadata, len(tracks))
+	for i, md := range tracks {
+		trackID, err := trackIDOf(md)
+		if err != nil {
+			return err
+		}
+		if _, ok := metadata[trackID]; ok {
+			return fmt.Errorf("track %q is not unique: %w", trackID, ErrInvalidMetadata)
+		}
+		trackIDs[i] = trackID
+		metadata[trackID] = maps.Clone(md)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.trackIDs = trackIDs
+	t.metadata = metadata
+
+	return t.emit(signalNameTrackListReplaced, slices.Clone(trackIDs), currentTrack)
+}
+
+// Add inserts the given track after afterTrack (NoTrack inserts it at the start) and emits TrackAdded. An error
+// wrapping ErrInvalidMetadata is returned if the track has no unique mpris:trackid and an error wrapping
+// ErrUnknownTrack if afterTrack is not part of the tracklist. The metadata is copied, so it may be modified afterwards.
+func (t *ServerTrackList) Add(md Metadata, afterTrack dbus.ObjectPath) error {
+	trackID, err := trackIDOf(md)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.metadata[trackID]; ok {
+		return fmt.Errorf("track %q is already part of the tracklist: %w", trackID, ErrInvalidMetadata)
+	}
+	i := 0
+	if afterTrack != NoTrack {
+		i = slices.Index(t.trackIDs, afterTrack) + 1
+		if i == 0 {
+			return fmt.Errorf("track %q: %w", afterTrack, ErrUnknownTrack)
+		}
+	}
+
+	md = maps.Clone(md)
+	t.trackIDs = slices.Insert(t.trackIDs, i, trackID)
+	t.metadata[trackID] = md
+
+	return t.emit(signalNameTrackAdded, map[string]dbus.Variant(md), afterTrack)
+}
+
+// Remove removes the given track and emits TrackRemoved. An error wrapping ErrUnknownTrack is returned if the track is
+// not part of the tracklist.
+func (t *ServerTrackList) Remove(trackID dbus.ObjectPath) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i := slices.Index(t.trackIDs, trackID)
+	if i < 0 {
+		return fmt.Errorf("track %q: %w", trackID, ErrUnknownTrack)
+	}
+
+	t.trackIDs = slices.Delete(t.trackIDs, i, i+1)
+	delete(t.metadata, trackID)
+
+	return t.emit(signalNameTrackRemoved, trackID)
+}
+
+// UpdateMetadata replaces the metadata of the given track and emits TrackMetadataChanged. The mpris:trackid of md may
+// differ from trackID to change the id of the track. An error wrapping ErrUnknownTrack is returned if the track is not
+// part of the tracklist and an error wrapping ErrInvalidMetadata if md has no unique mpris:trackid. The metadata is
+// copied, so it may be modified afterwards.
+func (t *ServerTrackList) UpdateMetadata(trackID dbus.ObjectPath, md Metadata) error {
+	newTrackID, err := trackIDOf(md)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i := slices.Index(t.trackIDs, trackID)
+	if i < 0 {
+		return fmt.Errorf("track %q: %w", trackID, ErrUnknownTrack)
+	}
+	if _, ok := t.metadata[newTrackID]; ok && newTrackID != trackID {
+		return fmt.Errorf("track %q is already part of the tracklist: %w", newTrackID, ErrInvalidMetadata)
+	}
+
+	md = maps.Clone(md)
+	t.trackIDs[i] = newTrackID
+	delete(t.metadata, trackID)
+	t.metadata[newTrackID] = md
+
+	return t.emit(signalNameTrackMetadataChanged, trackID, map[string]dbus.Variant(md))
+}
+
+// emit emits the given signal of org.mpris.MediaPlayer2.TrackList followed by PropertiesChanged invalidating the Tracks
+// property. It must be called with t.mu held to emit the signals in order.
+func (t *ServerTrackList) emit(name string, values ...interface{}) error {
+	var errs []error
+	err := t.connection.Emit(playerObjectPath, name, values...)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to emit %q: %w", name, err))
+	}
+
+	errs = append(errs, emitPropertiesChanged(t.connection, trackListInterface, map[string]dbus.Variant{}, []string{"Tracks"}))
+	return errors.Join(errs...)
+}
+
+// trackIDOf returns the mpris:trackid of the given metadata. An error wrapping ErrInvalidMetadata is returned if it is
+// missing, invalid or NoTrack.
+func trackIDOf(md Metadata) (dbus.ObjectPath, error) {
+	trackID, err := md.MPRISTrackID()
+	if err != nil {
+		return "", fmt.Errorf("invalid mpris:trackid: %w: %w", ErrInvalidMetadata, err)
+	}
+	if trackID == "" || trackID == NoTrack || !trackID.IsValid() {
+		return "", fmt.Errorf("invalid mpris:trackid %q: %w", trackID, ErrInvalidMetadata)
+	}
+
+	return trackID, nil
+}
+
+func (s *Server) trackListProperties() map[string]serverProperty {
+	t := s.trackList
+	return map[string]serverProperty{
+		trackListTracksProperty: {
+			get:      func(*ServerState) interface{} { return nonNil(t.Tracks()) },
+			volatile: true,
+		},
+		trackListCanEditTracksProperty: {
+			get:      func(*ServerState) interface{} { return t.CanEditTracks() },
+			volatile: true,
+		},
+	}
+}
+
+// serverTrackList implements the methods of org.mpris.MediaPlayer2.TrackList by delegating to the TrackListHandler.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html
+type serverTrackList struct {
+	trackList *ServerTrackList
+}
+
+// GetTracksMetadata returns the metadata of the given tracks. Tracks which are not part of the tracklist are skipped.
+func (t serverTrackList) GetTracksMetadata(trackIDs []dbus.ObjectPath) ([]map[string]dbus.Variant, *dbus.Error) {
+	metadata := make([]map[string]dbus.Variant, 0, len(trackIDs))
+	for _, trackID := range trackIDs {
+		if md, ok := t.trackList.Metadata(trackID); ok {
+			metadata = append(metadata, md)
+		}
+	}
+
+	return metadata, nil
+}
+
+func (t serverTrackList) AddTrack(uri string, afterTrack dbus.ObjectPath, setAsCurrent bool) *dbus.Error {
+	if !t.trackList.CanEditTracks() {
+		return nil
+	}
+	if _, ok := t.trackList.Metadata(afterTrack); !ok && afterTrack != NoTrack {
+		return unknownTrackError(afterTrack)
+	}
+
+	return handlerError(t.trackList.handler.AddTrack(uri, afterTrack, setAsCurrent))
+}
+
+// RemoveTrack removes the given track. Tracks which are not part of the tracklist are ignored as the spec requires.
+func (t serverTrackList) RemoveTrack(trackID dbus.ObjectPath) *dbus.Error {
+	if !t.trackList.CanEditTracks() {
+		return nil
+	}
+	if _, ok := t.trackList.Metadata(trackID); !ok {
+		return nil
+	}
+
+	err := t.trackList.handler.RemoveTrack(trackID)
+	if err != nil {
+		return handlerError(err)
+	}
+
+	err = t.trackList.Remove(trackID)
+	if errors.Is(err, ErrUnknownTrack) { // already removed by the handler
+		return nil
+	}
+	return handlerError(err)
+}
+
+// GoTo skips to the given track. Tracks which are not part of the tracklist are ignored as the spec requires.
+func (t serverTrackList) GoTo(trackID dbus.ObjectPath) *dbus.Error {
+	if _, ok := t.trackList.Metadata(trackID); !ok {
+		return nil
+	}
+
+	return handlerError(t.trackList.handler.GoTo(trackID))
+}
+
+func unknownTrackError(trackID dbus.ObjectPath) *dbus.Error {
+	return dbus.NewError(dbusErrorNameInvalidArgs, []interface{}{fmt.Sprintf("track %q: %s", trackID, ErrUnknownTrack)})
+}
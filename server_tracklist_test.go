@@ -0,0 +1,390 @@
+package mpris
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTrackListHandler is a testHandler which implements TrackListHandler additionally.
+type testTrackListHandler struct {
+	testHandler
+}
+
+func (h *testTrackListHandler) AddTrack(uri string, afterTrack dbus.ObjectPath, setAsCurrent bool) error {
+	return h.record("AddTrack", uri, afterTrack, setAsCurrent)
+}
+
+func (h *testTrackListHandler) RemoveTrack(trackID dbus.ObjectPath) error {
+	return h.record("RemoveTrack", trackID)
+}
+
+func (h *testTrackListHandler) GoTo(trackID dbus.ObjectPath) error {
+	return h.record("GoTo", trackID)
+}
+
+func testTrack(trackID dbus.ObjectPath) Metadata {
+	return Metadata{
+		"mpris:trackid": dbus.MakeVariant(trackID),
+		"xesam:title":   dbus.MakeVariant(string(trackID)),
+	}
+}
+
+func tracksInvalidated() emitted {
+	return emitted{
+		name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+		values: []interface{}{"org.mpris.MediaPlayer2.TrackList", map[string]dbus.Variant{}, []string{"Tracks"}},
+	}
+}
+
+func TestNewServer_TrackList(t *testing.T) {
+	var ifaces []string
+	connMock := &dbusServerConnMock{
+		ExportWithMapFunc: func(v interface{}, mapping map[string]string, path dbus.ObjectPath, iface string) error {
+			ifaces = append(ifaces, iface)
+			return nil
+		},
+		RequestNameFunc: func(name string, flags dbus.RequestNameFlags) (dbus.RequestNameReply, error) {
+			return dbus.RequestNameReplyPrimaryOwner, nil
+		},
+	}
+
+	s, err := newServer("test", &testTrackListHandler{}, ServerState{}, connMock)
+	require.NoError(t, err)
+	assert.Contains(t, ifaces, "org.mpris.MediaPlayer2.TrackList")
+	require.NotNil(t, s.TrackList())
+
+	v, dbusErr := serverProperties{server: s}.Get("org.mpris.MediaPlayer2", "HasTrackList")
+	require.Nil(t, dbusErr)
+	assert.Equal(t, dbus.MakeVariant(true), v)
+
+	s, err = newServer("test", &testHandler{}, ServerState{}, connMock)
+	require.NoError(t, err)
+	assert.Nil(t, s.TrackList())
+}
+
+func TestServerTrackList_Changes(t *testing.T) {
+	tests := []struct {
+		name            string
+		change          func(t *ServerTrackList) error
+		expectedTracks  []dbus.ObjectPath
+		expectedSignals []emitted
+		expectedErr     string
+		expectedErrIs   error
+	}{
+		{
+			name: "Replace",
+			change: func(t *ServerTrackList) error {
+				return t.Replace([]Metadata{testTrack("/track/3"), testTrack("/track/4")}, "/track/4")
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/3", "/track/4"},
+			expectedSignals: []emitted{
+				{name: "org.mpris.MediaPlayer2.TrackList.TrackListReplaced", values: []interface{}{[]dbus.ObjectPath{"/track/3", "/track/4"}, dbus.ObjectPath("/track/4")}},
+				tracksInvalidated(),
+			},
+		}, {
+			name: "Replace duplicated track",
+			change: func(t *ServerTrackList) error {
+				return t.Replace([]Metadata{testTrack("/track/3"), testTrack("/track/3")}, NoTrack)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/3\" is not unique: the metadata is invalid",
+			expectedErrIs:  ErrInvalidMetadata,
+		}, {
+			name: "Replace without trackid",
+			change: func(t *ServerTrackList) error {
+				return t.Replace([]Metadata{{}}, NoTrack)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "invalid mpris:trackid \"\": the metadata is invalid",
+			expectedErrIs:  ErrInvalidMetadata,
+		}, {
+			name: "Add",
+			change: func(t *ServerTrackList) error {
+				return t.Add(testTrack("/track/3"), "/track/1")
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/3", "/track/2"},
+			expectedSignals: []emitted{
+				{name: "org.mpris.MediaPlayer2.TrackList.TrackAdded", values: []interface{}{map[string]dbus.Variant(testTrack("/track/3")), dbus.ObjectPath("/track/1")}},
+				tracksInvalidated(),
+			},
+		}, {
+			name: "Add at the start",
+			change: func(t *ServerTrackList) error {
+				return t.Add(testTrack("/track/3"), NoTrack)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/3", "/track/1", "/track/2"},
+			expectedSignals: []emitted{
+				{name: "org.mpris.MediaPlayer2.TrackList.TrackAdded", values: []interface{}{map[string]dbus.Variant(testTrack("/track/3")), NoTrack}},
+				tracksInvalidated(),
+			},
+		}, {
+			name: "Add after unknown track",
+			change: func(t *ServerTrackList) error {
+				return t.Add(testTrack("/track/3"), "/track/9")
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/9\": the track is not part of the tracklist",
+			expectedErrIs:  ErrUnknownTrack,
+		}, {
+			name: "Add existing track",
+			change: func(t *ServerTrackList) error {
+				return t.Add(testTrack("/track/2"), NoTrack)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/2\" is already part of the tracklist: the metadata is invalid",
+			expectedErrIs:  ErrInvalidMetadata,
+		}, {
+			name: "Add NoTrack",
+			change: func(t *ServerTrackList) error {
+				return t.Add(testTrack(NoTrack), NoTrack)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "invalid mpris:trackid \"/org/mpris/MediaPlayer2/TrackList/NoTrack\": the metadata is invalid",
+			expectedErrIs:  ErrInvalidMetadata,
+		}, {
+			name: "Remove",
+			change: func(t *ServerTrackList) error {
+				return t.Remove("/track/1")
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/2"},
+			expectedSignals: []emitted{
+				{name: "org.mpris.MediaPlayer2.TrackList.TrackRemoved", values: []interface{}{dbus.ObjectPath("/track/1")}},
+				tracksInvalidated(),
+			},
+		}, {
+			name: "Remove unknown track",
+			change: func(t *ServerTrackList) error {
+				return t.Remove("/track/9")
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/9\": the track is not part of the tracklist",
+			expectedErrIs:  ErrUnknownTrack,
+		}, {
+			name: "UpdateMetadata",
+			change: func(t *ServerTrackList) error {
+				return t.UpdateMetadata("/track/2", testTrack("/track/3"))
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/3"},
+			expectedSignals: []emitted{
+				{name: "org.mpris.MediaPlayer2.TrackList.TrackMetadataChanged", values: []interface{}{dbus.ObjectPath("/track/2"), map[string]dbus.Variant(testTrack("/track/3"))}},
+				tracksInvalidated(),
+			},
+		}, {
+			name: "UpdateMetadata to existing track",
+			change: func(t *ServerTrackList) error {
+				return t.UpdateMetadata("/track/2", testTrack("/track/1"))
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/1\" is already part of the tracklist: the metadata is invalid",
+			expectedErrIs:  ErrInvalidMetadata,
+		}, {
+			name: "UpdateMetadata of unknown track",
+			change: func(t *ServerTrackList) error {
+				return t.UpdateMetadata("/track/9", testTrack("/track/9"))
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "track \"/track/9\": the track is not part of the tracklist",
+			expectedErrIs:  ErrUnknownTrack,
+		}, {
+			name: "SetCanEditTracks",
+			change: func(t *ServerTrackList) error {
+				return t.SetCanEditTracks(true)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedSignals: []emitted{{
+				name:   "org.freedesktop.DBus.Properties.PropertiesChanged",
+				values: []interface{}{"org.mpris.MediaPlayer2.TrackList", map[string]dbus.Variant{"CanEditTracks": dbus.MakeVariant(true)}, []string{}},
+			}},
+		}, {
+			name: "SetCanEditTracks unchanged",
+			change: func(t *ServerTrackList) error {
+				return t.SetCanEditTracks(false)
+			},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connMock, signals := newEmitRecorder(nil)
+			trackList := newServerTrackList(&testTrackListHandler{}, connMock)
+			require.NoError(t, trackList.Replace([]Metadata{testTrack("/track/1"), testTrack("/track/2")}, NoTrack))
+			*signals = nil
+
+			err := tt.change(trackList)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.ErrorIs(t, err, tt.expectedErrIs)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedTracks, trackList.Tracks())
+			assert.Equal(t, tt.expectedSignals, *signals)
+		})
+	}
+}
+
+func TestServerTrackList_EmitError(t *testing.T) {
+	connMock, signals := newEmitRecorder(errors.New("nope"))
+	trackList := newServerTrackList(&testTrackListHandler{}, connMock)
+
+	err := trackList.Add(testTrack("/track/1"), NoTrack)
+	assert.EqualError(t, err, "failed to emit \"org.mpris.MediaPlayer2.TrackList.TrackAdded\": nope\n"+
+		"failed to emit \"PropertiesChanged\" of \"org.mpris.MediaPlayer2.TrackList\": nope")
+	assert.Len(t, *signals, 2)
+	assert.Equal(t, []dbus.ObjectPath{"/track/1"}, trackList.Tracks())
+}
+
+func TestServerTrackList_MetadataCopied(t *testing.T) {
+	connMock, _ := newEmitRecorder(nil)
+	trackList := newServerTrackList(&testTrackListHandler{}, connMock)
+
+	replaced, added, updated := testTrack("/track/1"), testTrack("/track/2"), testTrack("/track/3")
+	require.NoError(t, trackList.Replace([]Metadata{replaced}, NoTrack))
+	require.NoError(t, trackList.Add(added, "/track/1"))
+	require.NoError(t, trackList.UpdateMetadata("/track/2", updated))
+	replaced["xesam:title"] = dbus.MakeVariant("changed")
+	added["xesam:title"] = dbus.MakeVariant("changed")
+	updated["xesam:title"] = dbus.MakeVariant("changed")
+
+	md, ok := trackList.Metadata("/track/1")
+	require.True(t, ok)
+	assert.Equal(t, testTrack("/track/1"), md)
+	md["xesam:title"] = dbus.MakeVariant("changed")
+	md, _ = trackList.Metadata("/track/1")
+	assert.Equal(t, testTrack("/track/1"), md)
+
+	metadata, dbusErr := serverTrackList{trackList: trackList}.GetTracksMetadata([]dbus.ObjectPath{"/track/3"})
+	require.Nil(t, dbusErr)
+	metadata[0]["xesam:title"] = dbus.MakeVariant("changed")
+	md, _ = trackList.Metadata("/track/3")
+	assert.Equal(t, testTrack("/track/3"), md)
+}
+
+func TestServerTrackList_Methods(t *testing.T) {
+	tests := []struct {
+		name           string
+		readOnly       bool
+		handlerErr     error
+		call           func(t serverTrackList) *dbus.Error
+		expectedCalls  []string
+		expectedArgs   []interface{}
+		expectedTracks []dbus.ObjectPath
+		expectedErr    string
+	}{
+		{
+			name:           "AddTrack",
+			call:           func(t serverTrackList) *dbus.Error { return t.AddTrack("file:///music.mp3", "/track/1", true) },
+			expectedCalls:  []string{"AddTrack"},
+			expectedArgs:   []interface{}{"file:///music.mp3", dbus.ObjectPath("/track/1"), true},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "AddTrack at the start",
+			call:           func(t serverTrackList) *dbus.Error { return t.AddTrack("file:///music.mp3", NoTrack, false) },
+			expectedCalls:  []string{"AddTrack"},
+			expectedArgs:   []interface{}{"file:///music.mp3", NoTrack, false},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "AddTrack after unknown track",
+			call:           func(t serverTrackList) *dbus.Error { return t.AddTrack("file:///music.mp3", "/track/9", false) },
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "org.freedesktop.DBus.Error.InvalidArgs",
+		}, {
+			name:           "AddTrack read-only",
+			readOnly:       true,
+			call:           func(t serverTrackList) *dbus.Error { return t.AddTrack("file:///music.mp3", "/track/1", true) },
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "RemoveTrack",
+			call:           func(t serverTrackList) *dbus.Error { return t.RemoveTrack("/track/1") },
+			expectedCalls:  []string{"RemoveTrack"},
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/1")},
+			expectedTracks: []dbus.ObjectPath{"/track/2"},
+		}, {
+			name:           "RemoveTrack handler error",
+			handlerErr:     errors.New("nope"),
+			call:           func(t serverTrackList) *dbus.Error { return t.RemoveTrack("/track/1") },
+			expectedCalls:  []string{"RemoveTrack"},
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/1")},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+			expectedErr:    "org.freedesktop.DBus.Error.Failed",
+		}, {
+			name:           "RemoveTrack unknown track",
+			call:           func(t serverTrackList) *dbus.Error { return t.RemoveTrack("/track/9") },
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "RemoveTrack read-only",
+			readOnly:       true,
+			call:           func(t serverTrackList) *dbus.Error { return t.RemoveTrack("/track/1") },
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "GoTo",
+			readOnly:       true,
+			call:           func(t serverTrackList) *dbus.Error { return t.GoTo("/track/2") },
+			expectedCalls:  []string{"GoTo"},
+			expectedArgs:   []interface{}{dbus.ObjectPath("/track/2")},
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		}, {
+			name:           "GoTo unknown track",
+			call:           func(t serverTrackList) *dbus.Error { return t.GoTo("/track/9") },
+			expectedTracks: []dbus.ObjectPath{"/track/1", "/track/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &testTrackListHandler{testHandler: testHandler{err: tt.handlerErr}}
+			connMock, _ := newEmitRecorder(nil)
+			trackList := newServerTrackList(h, connMock)
+			require.NoError(t, trackList.Replace([]Metadata{testTrack("/track/1"), testTrack("/track/2")}, NoTrack))
+			require.NoError(t, trackList.SetCanEditTracks(!tt.readOnly))
+
+			err := tt.call(serverTrackList{trackList: trackList})
+			if tt.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.expectedErr, err.Name)
+			} else {
+				assert.Nil(t, err)
+			}
+			calls, args := h.recorded()
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedArgs, args)
+			assert.Equal(t, tt.expectedTracks, trackList.Tracks())
+		})
+	}
+}
+
+func TestServerTrackList_GetTracksMetadata(t *testing.T) {
+	connMock, _ := newEmitRecorder(nil)
+	trackList := newServerTrackList(&testTrackListHandler{}, connMock)
+	require.NoError(t, trackList.Replace([]Metadata{testTrack("/track/1"), testTrack("/track/2")}, NoTrack))
+
+	metadata, err := serverTrackList{trackList: trackList}.GetTracksMetadata([]dbus.ObjectPath{"/track/2", "/track/9", "/track/1"})
+	require.Nil(t, err)
+	assert.Equal(t, []map[string]dbus.Variant{testTrack("/track/2"), testTrack("/track/1")}, metadata)
+}
+
+func TestServerTrackList_Properties(t *testing.T) {
+	connMock, _ := newEmitRecorder(nil)
+	s := newTestServer(&testTrackListHandler{}, newTestServerState(true), connMock)
+
+	values, err := serverProperties{server: s}.GetAll("org.mpris.MediaPlayer2.TrackList")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]dbus.Variant{
+		"Tracks":        dbus.MakeVariant([]dbus.ObjectPath{}),
+		"CanEditTracks": dbus.MakeVariant(false),
+	}, values)
+
+	require.NoError(t, s.TrackList().Add(testTrack("/track/1"), NoTrack))
+	v, err := serverProperties{server: s}.Get("org.mpris.MediaPlayer2.TrackList", "Tracks")
+	require.Nil(t, err)
+	assert.Equal(t, dbus.MakeVariant([]dbus.ObjectPath{"/track/1"}), v)
+
+	err = serverProperties{server: s}.Set("org.mpris.MediaPlayer2.TrackList", "CanEditTracks", dbus.MakeVariant(true))
+	require.NotNil(t, err)
+	assert.Equal(t, "org.freedesktop.DBus.Error.PropertyReadOnly", err.Name)
+}
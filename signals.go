@@ -0,0 +1,115 @@
+package mpris
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// signalBufferSize is the capacity of the channel which receives the raw signals of a subscription.
+const signalBufferSize = 16
+
+// subscribe adds a match rule with the given options and calls handle for every signal received by the connection
+// until ctx is done or the connection has been closed. Afterwards the match rule is removed and done is called.
+// The signals are handled in order of their arrival. They are queued without limit while handle blocks, so the channel
+// registered at the connection never fills up (the connection would deliver further signals out of order otherwise).
+// Note: the connection delivers all signals to every registered channel, handle has to filter irrelevant ones.
+func subscribe(ctx context.Context, connection dbusConn, options []dbus.MatchOption, handle func(sig *dbus.Signal), done func()) error {
+	err := connection.AddMatchSignal(options...)
+	if err != nil {
+		return fmt.Errorf("failed to add signal match option: %w", err)
+	}
+
+	signals := make(chan *dbus.Signal, signalBufferSize)
+	connection.Signal(signals)
+
+	queue := newSignalQueue()
+	go func() {
+		defer queue.close()
+		defer func() {
+			connection.RemoveSignal(signals)
+			_ = connection.RemoveMatchSignal(options...) // connection may already be closed
+		}()
+
+		for {
+			select {
+			case sig, ok := <-signals:
+				if !ok { // connection has been closed
+					return
+				}
+				queue.push(sig)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer done()
+
+		for {
+			sig, ok := queue.pop()
+			if !ok {
+				return
+			}
+			if ctx.Err() == nil {
+				handle(sig)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// signalQueue is an unbounded FIFO queue of signals. It is safe for concurrent use.
+type signalQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	signals []*dbus.Signal
+	closed  bool
+}
+
+func newSignalQueue() *signalQueue {
+	q := &signalQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push appends the given signal to the queue.
+func (q *signalQueue) push(sig *dbus.Signal) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.signals = append(q.signals, sig)
+	q.cond.Signal()
+}
+
+// close marks the queue as closed. Queued signals can still be popped.
+func (q *signalQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Signal()
+}
+
+// pop removes and returns the first signal of the queue. It blocks until a signal is available. false is returned when
+// the queue has been closed and all signals have been popped.
+func (q *signalQueue) pop() (*dbus.Signal, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.signals) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.signals) == 0 {
+		return nil, false
+	}
+
+	sig := q.signals[0]
+	q.signals[0] = nil // allow the signal to be garbage collected
+	q.signals = q.signals[1:]
+	return sig, true
+}
@@ -0,0 +1,95 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// PlayerState is a snapshot of the properties of a player. It can be encoded as JSON (e.g. to be logged or sent to a
+// client) and decoded again without losing information.
+type PlayerState struct {
+	PlaybackStatus PlaybackStatus `json:"playbackStatus"`
+	// LoopStatus is empty if the player does not support looping.
+	LoopStatus LoopStatus `json:"loopStatus,omitempty"`
+	Rate       float64    `json:"rate"`
+	// Shuffle is nil if the player does not support shuffling.
+	Shuffle  *bool    `json:"shuffle,omitempty"`
+	Metadata Metadata `json:"metadata"`
+	Volume   float64  `json:"volume"`
+	// Position is encoded in JSON as nanoseconds.
+	Position      time.Duration `json:"position"`
+	MinimumRate   float64       `json:"minimumRate"`
+	MaximumRate   float64       `json:"maximumRate"`
+	CanGoNext     bool          `json:"canGoNext"`
+	CanGoPrevious bool          `json:"canGoPrevious"`
+	CanPlay       bool          `json:"canPlay"`
+	CanPause      bool          `json:"canPause"`
+	CanSeek       bool          `json:"canSeek"`
+	CanControl    bool          `json:"canControl"`
+}
+
+// Snapshot returns the values of all properties of the player at once (via org.freedesktop.DBus.Properties.GetAll).
+// In contrast to reading the properties one by one, all values belong to the same point in time (e.g. Metadata and
+// Position do not belong to different tracks).
+// Properties which are not provided by the player are left empty. Properties which could not be decoded are left empty
+// as well and their errors are returned joined (see errors.Join).
+// see: https://dbus.freedesktop.org/doc/dbus-specification.html#standard-interfaces-properties
+func (p Player) Snapshot() (PlayerState, error) {
+	return p.SnapshotContext(context.Background())
+}
+
+// SnapshotContext is like Snapshot but uses the given context for the dbus call.
+func (p Player) SnapshotContext(ctx context.Context) (PlayerState, error) {
+	var properties map[string]dbus.Variant
+	err := p.callAndStore(ctx, propertiesGetAllMethod, []interface{}{playerInterface}, &properties)
+	if err != nil {
+		return PlayerState{}, err
+	}
+
+	return newPlayerState(properties)
+}
+
+func newPlayerState(properties map[string]dbus.Variant) (PlayerState, error) {
+	var errs []error
+	var s PlayerState
+
+	s.PlaybackStatus = PlaybackStatus(decodePlayerProperty(properties, "PlaybackStatus", decodeValue[string], &errs))
+	s.LoopStatus = LoopStatus(decodePlayerProperty(properties, "LoopStatus", decodeValue[string], &errs))
+	s.Rate = decodePlayerProperty(properties, "Rate", decodeFloat64, &errs)
+	if _, ok := properties["Shuffle"]; ok {
+		shuffle := decodePlayerProperty(properties, "Shuffle", decodeValue[bool], &errs)
+		s.Shuffle = &shuffle
+	}
+	s.Metadata = decodePlayerProperty(properties, "Metadata", decodeValue[map[string]dbus.Variant], &errs)
+	s.Volume = decodePlayerProperty(properties, "Volume", decodeFloat64, &errs)
+	s.Position = microseconds(decodePlayerProperty(properties, "Position", decodeInt64, &errs))
+	s.MinimumRate = decodePlayerProperty(properties, "MinimumRate", decodeFloat64, &errs)
+	s.MaximumRate = decodePlayerProperty(properties, "MaximumRate", decodeFloat64, &errs)
+	s.CanGoNext = decodePlayerProperty(properties, "CanGoNext", decodeValue[bool], &errs)
+	s.CanGoPrevious = decodePlayerProperty(properties, "CanGoPrevious", decodeValue[bool], &errs)
+	s.CanPlay = decodePlayerProperty(properties, "CanPlay", decodeValue[bool], &errs)
+	s.CanPause = decodePlayerProperty(properties, "CanPause", decodeValue[bool], &errs)
+	s.CanSeek = decodePlayerProperty(properties, "CanSeek", decodeValue[bool], &errs)
+	s.CanControl = decodePlayerProperty(properties, "CanControl", decodeValue[bool], &errs)
+
+	return s, errors.Join(errs...)
+}
+
+// decodePlayerProperty decodes the property with the given name (without interface) if present. Decode errors are
+// appended to errs.
+func decodePlayerProperty[T any](properties map[string]dbus.Variant, name string, decode func(string, dbus.Variant) (T, error), errs *[]error) T {
+	v, ok := properties[name]
+	if !ok {
+		var t T
+		return t
+	}
+
+	t, err := decode(playerInterface+"."+name, v)
+	if err != nil {
+		*errs = append(*errs, err)
+	}
+	return t
+}
@@ -0,0 +1,124 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayer_Snapshot(t *testing.T) {
+	shuffle := false
+
+	tests := []struct {
+		name            string
+		givenProperties map[string]dbus.Variant
+		callErr         error
+		expectedState   PlayerState
+		expectedErr     string
+	}{
+		{
+			name: "happycase",
+			givenProperties: map[string]dbus.Variant{
+				"PlaybackStatus": dbus.MakeVariant("Playing"),
+				"LoopStatus":     dbus.MakeVariant("Playlist"),
+				"Rate":           dbus.MakeVariant(1.0),
+				"Shuffle":        dbus.MakeVariant(false),
+				"Metadata": dbus.MakeVariant(map[string]dbus.Variant{
+					"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				}),
+				"Volume":        dbus.MakeVariant(int32(1)),
+				"Position":      dbus.MakeVariant(int64(1500000)),
+				"MinimumRate":   dbus.MakeVariant(0.5),
+				"MaximumRate":   dbus.MakeVariant(2.0),
+				"CanGoNext":     dbus.MakeVariant(true),
+				"CanGoPrevious": dbus.MakeVariant(true),
+				"CanPlay":       dbus.MakeVariant(true),
+				"CanPause":      dbus.MakeVariant(true),
+				"CanSeek":       dbus.MakeVariant(true),
+				"CanControl":    dbus.MakeVariant(true),
+			},
+			expectedState: PlayerState{
+				PlaybackStatus: PlaybackStatusPlaying,
+				LoopStatus:     LoopStatusPlaylist,
+				Rate:           1,
+				Shuffle:        &shuffle,
+				Metadata: Metadata{
+					"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				},
+				Volume:        1,
+				Position:      1500 * time.Millisecond,
+				MinimumRate:   0.5,
+				MaximumRate:   2,
+				CanGoNext:     true,
+				CanGoPrevious: true,
+				CanPlay:       true,
+				CanPause:      true,
+				CanSeek:       true,
+				CanControl:    true,
+			},
+		}, {
+			name: "optional properties missing",
+			givenProperties: map[string]dbus.Variant{
+				"PlaybackStatus": dbus.MakeVariant("Stopped"),
+				"CanControl":     dbus.MakeVariant(false),
+			},
+			expectedState: PlayerState{
+				PlaybackStatus: PlaybackStatusStopped,
+			},
+		}, {
+			name: "decode errors",
+			givenProperties: map[string]dbus.Variant{
+				"PlaybackStatus": dbus.MakeVariant("Paused"),
+				"Rate":           dbus.MakeVariant("fast"),
+				"CanSeek":        dbus.MakeVariant(int32(1)),
+			},
+			expectedState: PlayerState{
+				PlaybackStatus: PlaybackStatusPaused,
+			},
+			expectedErr: "property \"org.mpris.MediaPlayer2.Player.Rate\" of type \"s\" could not be parsed to float64: the given type is not as expected\n" +
+				"property \"org.mpris.MediaPlayer2.Player.CanSeek\" of type \"i\" could not be parsed to bool: the given type is not as expected",
+		}, {
+			name:        "call error",
+			callErr:     errors.New("nope"),
+			expectedErr: "failed to call method \"org.freedesktop.DBus.Properties.GetAll\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenMethod string
+			var givenArgs []interface{}
+
+			state, err := Player{
+				name: "snapshot",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						assert.Equal(t, "snapshot", dest)
+						assert.Equal(t, dbus.ObjectPath("/org/mpris/MediaPlayer2"), path)
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, _ dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(retvalues ...interface{}) error {
+										*retvalues[0].(*map[string]dbus.Variant) = tt.givenProperties
+										return tt.callErr
+									},
+								}
+							},
+						}
+					},
+				},
+			}.Snapshot()
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedState, state)
+			assert.Equal(t, "org.freedesktop.DBus.Properties.GetAll", givenMethod)
+			assert.Equal(t, []interface{}{"org.mpris.MediaPlayer2.Player"}, givenArgs)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package mpris
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Track contains all known fields of Metadata.
+// see: https://www.freedesktop.org/wiki/Specifications/mpris-spec/metadata/
+type Track struct {
+	// TrackID is the value of "mpris:trackid", see Metadata.MPRISTrackID.
+	TrackID dbus.ObjectPath
+	// Length is the value of "mpris:length", see Metadata.Length.
+	Length time.Duration
+	// ArtURL is the value of "mpris:artUrl", see Metadata.MPRISArtURL.
+	ArtURL string
+	// Album is the value of "xesam:album", see Metadata.XESAMAlbum.
+	Album string
+	// AlbumArtist is the value of "xesam:albumArtist", see Metadata.XESAMAlbumArtist.
+	AlbumArtist []string
+	// Artist is the value of "xesam:artist", see Metadata.XESAMArtist.
+	Artist []string
+	// AsText is the value of "xesam:asText", see Metadata.XESAMAsText.
+	AsText string
+	// AudioBPM is the value of "xesam:audioBPM", see Metadata.XESAMAudioBPM.
+	AudioBPM int
+	// AutoRating is the value of "xesam:autoRating", see Metadata.XESAMAutoRating.
+	AutoRating float64
+	// Comment is the value of "xesam:comment", see Metadata.XESAMComment.
+	Comment []string
+	// Composer is the value of "xesam:composer", see Metadata.XESAMComposer.
+	Composer []string
+	// ContentCreated is the value of "xesam:contentCreated", see Metadata.XESAMContentCreated.
+	ContentCreated time.Time
+	// DiscNumber is the value of "xesam:discNumber", see Metadata.XESAMDiscNumber.
+	DiscNumber int
+	// FirstUsed is the value of "xesam:firstUsed", see Metadata.XESAMFirstUsed.
+	FirstUsed time.Time
+	// Genre is the value of "xesam:genre", see Metadata.XESAMGenre.
+	Genre []string
+	// LastUsed is the value of "xesam:lastUsed", see Metadata.XESAMLastUsed.
+	LastUsed time.Time
+	// Lyricist is the value of "xesam:lyricist", see Metadata.XESAMLyricist.
+	Lyricist []string
+	// Title is the value of "xesam:title", see Metadata.XESAMTitle.
+	Title string
+	// TrackNumber is the value of "xesam:trackNumber", see Metadata.XESAMTrackNumber.
+	TrackNumber int
+	// URL is the value of "xesam:url", see Metadata.XESAMURL.
+	URL string
+	// UseCount is the value of "xesam:useCount", see Metadata.XESAMUseCount.
+	UseCount int
+	// UserRating is the value of "xesam:userRating", see Metadata.XESAMUserRating.
+	UserRating float64
+
+	// Extra contains all fields which are not known (e.g. player specific ones). It is nil if there are none.
+	Extra map[string]dbus.Variant
+}
+
+// Track decodes all known fields of the metadata into a Track. Fields which are not known are kept in Track.Extra.
+// Fields which could not be decoded are left empty and their errors are returned joined (see errors.Join). Therefore,
+// the returned Track is usable even if an error is returned.
+func (md Metadata) Track() (Track, error) {
+	var t Track
+	var errs []error
+	collect := func(key string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode %q: %w", key, err))
+		}
+	}
+
+	var err error
+	t.TrackID, err = md.MPRISTrackID()
+	collect("mpris:trackid", err)
+	t.Length, err = md.Length()
+	collect("mpris:length", err)
+	t.ArtURL, err = md.MPRISArtURL()
+	collect("mpris:artUrl", err)
+	t.Album, err = md.XESAMAlbum()
+	collect("xesam:album", err)
+	t.AlbumArtist, err = md.XESAMAlbumArtist()
+	collect("xesam:albumArtist", err)
+	t.Artist, err = md.XESAMArtist()
+	collect("xesam:artist", err)
+	t.AsText, err = md.XESAMAsText()
+	collect("xesam:asText", err)
+	t.AudioBPM, err = md.XESAMAudioBPM()
+	collect("xesam:audioBPM", err)
+	t.AutoRating, err = md.XESAMAutoRating()
+	collect("xesam:autoRating", err)
+	t.Comment, err = md.XESAMComment()
+	collect("xesam:comment", err)
+	t.Composer, err = md.XESAMComposer()
+	collect("xesam:composer", err)
+	t.ContentCreated, err = md.XESAMContentCreated()
+	collect("xesam:contentCreated", err)
+	t.DiscNumber, err = md.XESAMDiscNumber()
+	collect("xesam:discNumber", err)
+	t.FirstUsed, err = md.XESAMFirstUsed()
+	collect("xesam:firstUsed", err)
+	t.Genre, err = md.XESAMGenre()
+	collect("xesam:genre", err)
+	t.LastUsed, err = md.XESAMLastUsed()
+	collect("xesam:lastUsed", err)
+	t.Lyricist, err = md.XESAMLyricist()
+	collect("xesam:lyricist", err)
+	t.Title, err = md.XESAMTitle()
+	collect("xesam:title", err)
+	t.TrackNumber, err = md.XESAMTrackNumber()
+	collect("xesam:trackNumber", err)
+	t.URL, err = md.XESAMURL()
+	collect("xesam:url", err)
+	t.UseCount, err = md.XESAMUseCount()
+	collect("xesam:useCount", err)
+	t.UserRating, err = md.XESAMUserRating()
+	collect("xesam:userRating", err)
+
+	for key, v := range md {
+		if _, ok := metadataTypes[key]; ok {
+			continue
+		}
+		if t.Extra == nil {
+			t.Extra = map[string]dbus.Variant{}
+		}
+		t.Extra[key] = v
+	}
+
+	return t, errors.Join(errs...)
+}
+
+// metadataTypes contains the dbus types (as go types) of all known metadata fields.
+var metadataTypes = map[string]reflect.Type{
+	"mpris:trackid":        reflect.TypeOf(dbus.ObjectPath("")),
+	"mpris:length":         reflect.TypeOf(int64(0)),
+	"mpris:artUrl":         reflect.TypeOf(""),
+	"xesam:album":          reflect.TypeOf(""),
+	"xesam:albumArtist":    reflect.TypeOf([]string{}),
+	"xesam:artist":         reflect.TypeOf([]string{}),
+	"xesam:asText":         reflect.TypeOf(""),
+	"xesam:audioBPM":       reflect.TypeOf(int32(0)),
+	"xesam:autoRating":     reflect.TypeOf(float64(0)),
+	"xesam:comment":        reflect.TypeOf([]string{}),
+	"xesam:composer":       reflect.TypeOf([]string{}),
+	"xesam:contentCreated": reflect.TypeOf(""),
+	"xesam:discNumber":     reflect.TypeOf(int32(0)),
+	"xesam:firstUsed":      reflect.TypeOf(""),
+	"xesam:genre":          reflect.TypeOf([]string{}),
+	"xesam:lastUsed":       reflect.TypeOf(""),
+	"xesam:lyricist":       reflect.TypeOf([]string{}),
+	"xesam:title":          reflect.TypeOf(""),
+	"xesam:trackNumber":    reflect.TypeOf(int32(0)),
+	"xesam:url":            reflect.TypeOf(""),
+	"xesam:useCount":       reflect.TypeOf(int32(0)),
+	"xesam:userRating":     reflect.TypeOf(float64(0)),
+}
+
+// NewMetadata encodes the given track into Metadata with the dbus types defined by the specification (e.g. int32 for
+// Track.TrackNumber). Empty fields are omitted, dates are encoded in ISO 8601 format and Track.Extra is added as is.
+// An error wrapping ErrInvalidMetadata is returned when Track.TrackID is not a valid object path (it is required), an
+// integer field does not fit into an int32 or Track.Extra contains a known field.
+func NewMetadata(t Track) (Metadata, error) {
+	var errs []error
+	if t.TrackID == "" {
+		errs = append(errs, fmt.Errorf("%q is required: %w", "mpris:trackid", ErrInvalidMetadata))
+	} else if !t.TrackID.IsValid() {
+		errs = append(errs, fmt.Errorf("%q is not a valid object path: %w", "mpris:trackid", ErrInvalidMetadata))
+	}
+
+	md := Metadata{}
+	put := func(key string, value interface{}, empty bool) {
+		if !empty {
+			md[key] = dbus.MakeVariant(value)
+		}
+	}
+	putInt := func(key string, value int) {
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			errs = append(errs, fmt.Errorf("%q does not fit into an int32: %w", key, ErrInvalidMetadata))
+			return
+		}
+		put(key, int32(value), value == 0)
+	}
+	putDate := func(key string, value time.Time) {
+		put(key, value.Format(time.RFC3339Nano), value.IsZero())
+	}
+
+	put("mpris:trackid", t.TrackID, t.TrackID == "")
+	put("mpris:length", t.Length.Microseconds(), t.Length == 0)
+	put("mpris:artUrl", t.ArtURL, t.ArtURL == "")
+	put("xesam:album", t.Album, t.Album == "")
+	put("xesam:albumArtist", t.AlbumArtist, len(t.AlbumArtist) == 0)
+	put("xesam:artist", t.Artist, len(t.Artist) == 0)
+	put("xesam:asText", t.AsText, t.AsText == "")
+	putInt("xesam:audioBPM", t.AudioBPM)
+	put("xesam:autoRating", t.AutoRating, t.AutoRating == 0)
+	put("xesam:comment", t.Comment, len(t.Comment) == 0)
+	put("xesam:composer", t.Composer, len(t.Composer) == 0)
+	putDate("xesam:contentCreated", t.ContentCreated)
+	putInt("xesam:discNumber", t.DiscNumber)
+	putDate("xesam:firstUsed", t.FirstUsed)
+	put("xesam:genre", t.Genre, len(t.Genre) == 0)
+	putDate("xesam:lastUsed", t.LastUsed)
+	put("xesam:lyricist", t.Lyricist, len(t.Lyricist) == 0)
+	put("xesam:title", t.Title, t.Title == "")
+	putInt("xesam:trackNumber", t.TrackNumber)
+	put("xesam:url", t.URL, t.URL == "")
+	putInt("xesam:useCount", t.UseCount)
+	put("xesam:userRating", t.UserRating, t.UserRating == 0)
+
+	for key, v := range t.Extra {
+		if _, ok := metadataTypes[key]; ok {
+			errs = append(errs, fmt.Errorf("%q is a known field and must not be part of Extra: %w", key, ErrInvalidMetadata))
+			continue
+		}
+		md[key] = v
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return md, nil
+}
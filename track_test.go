@@ -0,0 +1,216 @@
+package mpris
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_Track(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenMetadata string
+		expectedTrack Track
+		expectedErr   string
+	}{
+		{
+			name: "happycase",
+			givenMetadata: `{
+				'mpris:trackid': <objectpath '/org/mpris/MediaPlayer2/Track/1'>,
+				'mpris:length': <int64 215000000>,
+				'mpris:artUrl': <'file:///art.png'>,
+				'xesam:album': <'Album'>,
+				'xesam:albumArtist': <['Album Artist']>,
+				'xesam:artist': <['Artist 1', 'Artist 2']>,
+				'xesam:asText': <'Lyrics'>,
+				'xesam:audioBPM': <int32 120>,
+				'xesam:autoRating': <0.5>,
+				'xesam:comment': <['Comment']>,
+				'xesam:composer': <['Composer']>,
+				'xesam:contentCreated': <'2019'>,
+				'xesam:discNumber': <int32 1>,
+				'xesam:firstUsed': <'2020-01-02T03:04:05Z'>,
+				'xesam:genre': <['Rock']>,
+				'xesam:lastUsed': <'2021-01-02'>,
+				'xesam:lyricist': <['Lyricist']>,
+				'xesam:title': <'Title'>,
+				'xesam:trackNumber': <int32 3>,
+				'xesam:url': <'file:///track.mp3'>,
+				'xesam:useCount': <int32 7>,
+				'xesam:userRating': <1.0>,
+				'vlc:nowplaying': <'Stream'>
+			}`,
+			expectedTrack: Track{
+				TrackID:        "/org/mpris/MediaPlayer2/Track/1",
+				Length:         215 * time.Second,
+				ArtURL:         "file:///art.png",
+				Album:          "Album",
+				AlbumArtist:    []string{"Album Artist"},
+				Artist:         []string{"Artist 1", "Artist 2"},
+				AsText:         "Lyrics",
+				AudioBPM:       120,
+				AutoRating:     0.5,
+				Comment:        []string{"Comment"},
+				Composer:       []string{"Composer"},
+				ContentCreated: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				DiscNumber:     1,
+				FirstUsed:      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				Genre:          []string{"Rock"},
+				LastUsed:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				Lyricist:       []string{"Lyricist"},
+				Title:          "Title",
+				TrackNumber:    3,
+				URL:            "file:///track.mp3",
+				UseCount:       7,
+				UserRating:     1,
+				Extra: map[string]dbus.Variant{
+					"vlc:nowplaying": dbus.MakeVariant("Stream"),
+				},
+			},
+		}, {
+			name:          "empty",
+			givenMetadata: "@a{sv} {}",
+		}, {
+			name: "decode errors",
+			givenMetadata: `{
+				'mpris:trackid': <'/not/an/objectpath'>,
+				'xesam:title': <'Title'>,
+				'xesam:trackNumber': <'three'>
+			}`,
+			expectedTrack: Track{
+				Title: "Title",
+			},
+			expectedErr: "failed to decode \"mpris:trackid\": string could not be parsed to dbus.ObjectPath: the given type is not as expected\n" +
+				"failed to decode \"xesam:trackNumber\": string could not be parsed to int: the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, err := parseMetadata(t, tt.givenMetadata).Track()
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedTrack, track)
+		})
+	}
+}
+
+func TestNewMetadata(t *testing.T) {
+	track := Track{
+		TrackID:        "/org/mpris/MediaPlayer2/Track/1",
+		Length:         215 * time.Second,
+		ArtURL:         "file:///art.png",
+		Album:          "Album",
+		AlbumArtist:    []string{"Album Artist"},
+		Artist:         []string{"Artist 1", "Artist 2"},
+		AsText:         "Lyrics",
+		AudioBPM:       120,
+		AutoRating:     0.5,
+		Comment:        []string{"Comment"},
+		Composer:       []string{"Composer"},
+		ContentCreated: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		DiscNumber:     1,
+		FirstUsed:      time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC),
+		Genre:          []string{"Rock"},
+		LastUsed:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		Lyricist:       []string{"Lyricist"},
+		Title:          "Title",
+		TrackNumber:    3,
+		URL:            "file:///track.mp3",
+		UseCount:       7,
+		UserRating:     1,
+		Extra: map[string]dbus.Variant{
+			"vlc:nowplaying": dbus.MakeVariant("Stream"),
+		},
+	}
+
+	md, err := NewMetadata(track)
+	assert.NoError(t, err)
+
+	signatures := map[string]string{}
+	for key, v := range md {
+		signatures[key] = v.Signature().String()
+	}
+	assert.Equal(t, map[string]string{
+		"mpris:trackid":        "o",
+		"mpris:length":         "x",
+		"mpris:artUrl":         "s",
+		"xesam:album":          "s",
+		"xesam:albumArtist":    "as",
+		"xesam:artist":         "as",
+		"xesam:asText":         "s",
+		"xesam:audioBPM":       "i",
+		"xesam:autoRating":     "d",
+		"xesam:comment":        "as",
+		"xesam:composer":       "as",
+		"xesam:contentCreated": "s",
+		"xesam:discNumber":     "i",
+		"xesam:firstUsed":      "s",
+		"xesam:genre":          "as",
+		"xesam:lastUsed":       "s",
+		"xesam:lyricist":       "as",
+		"xesam:title":          "s",
+		"xesam:trackNumber":    "i",
+		"xesam:url":            "s",
+		"xesam:useCount":       "i",
+		"xesam:userRating":     "d",
+		"vlc:nowplaying":       "s",
+	}, signatures)
+	assert.Equal(t, "2020-01-02T03:04:05.6Z", md["xesam:firstUsed"].Value())
+
+	decoded, err := md.Track()
+	assert.NoError(t, err)
+	assert.Equal(t, track, decoded)
+}
+
+func TestNewMetadata_Minimal(t *testing.T) {
+	md, err := NewMetadata(Track{TrackID: NoTrack})
+	assert.NoError(t, err)
+	assert.Equal(t, Metadata{
+		"mpris:trackid": dbus.MakeVariant(NoTrack),
+	}, md)
+}
+
+func TestNewMetadata_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenTrack  Track
+		expectedErr string
+	}{
+		{
+			name:        "missing trackid",
+			givenTrack:  Track{Title: "Title"},
+			expectedErr: "\"mpris:trackid\" is required: the metadata is invalid",
+		}, {
+			name:        "invalid trackid",
+			givenTrack:  Track{TrackID: "no/object/path"},
+			expectedErr: "\"mpris:trackid\" is not a valid object path: the metadata is invalid",
+		}, {
+			name:        "int32 overflow",
+			givenTrack:  Track{TrackID: "/track/1", TrackNumber: math.MaxInt32 + 1},
+			expectedErr: "\"xesam:trackNumber\" does not fit into an int32: the metadata is invalid",
+		}, {
+			name: "known field in extra",
+			givenTrack: Track{TrackID: "/track/1", Extra: map[string]dbus.Variant{
+				"xesam:title": dbus.MakeVariant("Title"),
+			}},
+			expectedErr: "\"xesam:title\" is a known field and must not be part of Extra: the metadata is invalid",
+		}, {
+			name:       "multiple errors",
+			givenTrack: Track{UseCount: math.MinInt32 - 1},
+			expectedErr: "\"mpris:trackid\" is required: the metadata is invalid\n" +
+				"\"xesam:useCount\" does not fit into an int32: the metadata is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := NewMetadata(tt.givenTrack)
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.ErrorIs(t, err, ErrInvalidMetadata)
+			assert.Nil(t, md)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package mpris
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// PositionTracker tracks the playback position of a player without polling it.
+// Media players do not emit changes of the Position property. Therefore, the position is read once and extrapolated
+// using the Rate and PlaybackStatus properties. It is synchronized again whenever the player emits Seeked or
+// PropertiesChanged (e.g. when the track has been changed).
+// Use NewPositionTracker to create a new instance and PositionTracker.Stop to stop it.
+type PositionTracker struct {
+	player Player
+	now    func() time.Time
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	position time.Duration
+	syncedAt time.Time
+	status   PlaybackStatus
+	rate     float64
+	trackID  dbus.ObjectPath
+	length   time.Duration
+}
+
+// NewPositionTracker returns a new PositionTracker for the given player. It reads the current position, rate, playback
+// status and metadata of the player and keeps track of their changes until the given context is done or Stop is called.
+func NewPositionTracker(ctx context.Context, p Player) (*PositionTracker, error) {
+	return newPositionTracker(ctx, p, time.Now)
+}
+
+func newPositionTracker(ctx context.Context, p Player, now func() time.Time) (*PositionTracker, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// subscribe before reading the state to not miss any change in between
+	changes, err := p.Changes(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	seeked, err := p.SeekedDuration(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	t := &PositionTracker{
+		player: p,
+		now:    now,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	err = t.sync(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(t.done)
+		defer cancel()
+		t.run(ctx, changes, seeked)
+	}()
+
+	return t, nil
+}
+
+// Now returns the current playback position of the player. It never exceeds the length of the current track, if known.
+func (t *PositionTracker) Now() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.current(t.now())
+}
+
+// Stop stops tracking the player and waits until the tracker has stopped. Now keeps returning the position extrapolated
+// from the last known state afterwards.
+func (t *PositionTracker) Stop() {
+	t.cancel()
+	<-t.done
+}
+
+// Done returns a channel which is closed when the tracker has stopped: Stop has been called, the context given to
+// NewPositionTracker is done or the connection has been closed. Afterwards Now only extrapolates the last known state.
+// Note: the tracker does not stop when the player quits, use WatchPlayers to detect this and Stop the tracker.
+func (t *PositionTracker) Done() <-chan struct{} {
+	return t.done
+}
+
+// Ticker emits the current playback position (see Now) in the given interval until the given context is done or the
+// tracker has stopped (see Done). The returned channel will be closed afterwards.
+func (t *PositionTracker) Ticker(ctx context.Context, interval time.Duration) <-chan time.Duration {
+	positions := make(chan time.Duration)
+	go func() {
+		defer close(positions)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			case <-t.done:
+				return
+			}
+
+			select {
+			case positions <- t.Now():
+			case <-ctx.Done():
+				return
+			case <-t.done:
+				return
+			}
+		}
+	}()
+
+	return positions
+}
+
+func (t *PositionTracker) run(ctx context.Context, changes <-chan PlayerChange, seeked <-chan time.Duration) {
+	for changes != nil || seeked != nil {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			t.change(ctx, change)
+		case position, ok := <-seeked:
+			if !ok {
+				seeked = nil
+				continue
+			}
+			t.mu.Lock()
+			t.position = position
+			t.syncedAt = t.now()
+			t.mu.Unlock()
+		}
+	}
+}
+
+// sync reads the whole state from the player.
+func (t *PositionTracker) sync(ctx context.Context) error {
+	status, err := t.player.PlaybackStatusContext(ctx)
+	if err != nil {
+		return err
+	}
+	rate, err := t.player.RateContext(ctx)
+	if err != nil {
+		return err
+	}
+	md, err := t.player.MetadataContext(ctx)
+	if err != nil {
+		return err
+	}
+	trackID, _ := md.MPRISTrackID() // unknown track
+	length, _ := md.Length()        // unknown length
+	position, err := t.player.PositionDurationContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.position = position
+	t.syncedAt = t.now()
+	t.status = status
+	t.rate = rate
+	t.trackID = trackID
+	t.length = length
+	return nil
+}
+
+// change applies the given change and reads the position from the player again. When the position is not available,
+// the position is extrapolated until the change.
+func (t *PositionTracker) change(ctx context.Context, change PlayerChange) {
+	t.mu.Lock()
+	now := t.now()
+	t.position = t.current(now)
+	t.syncedAt = now
+	if change.PlaybackStatus != nil {
+		t.status = *change.PlaybackStatus
+	}
+	if change.Rate != nil {
+		t.rate = *change.Rate
+	}
+	if change.Metadata != nil {
+		trackID, _ := change.Metadata.MPRISTrackID()
+		if trackID != t.trackID { // track has been changed
+			t.trackID = trackID
+			t.position = 0
+		}
+		t.length, _ = change.Metadata.Length()
+	}
+	t.mu.Unlock()
+
+	position, err := t.player.PositionDurationContext(ctx)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.position = position
+	t.syncedAt = t.now()
+	t.mu.Unlock()
+}
+
+// current returns the position at the given time. t.mu must be held.
+func (t *PositionTracker) current(now time.Time) time.Duration {
+	position := t.position
+	if t.status == PlaybackStatusPlaying {
+		position += time.Duration(float64(now.Sub(t.syncedAt)) * t.rate)
+	}
+
+	if position < 0 {
+		return 0
+	}
+	if t.length > 0 && position > t.length {
+		return t.length
+	}
+	return position
+}
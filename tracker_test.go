@@ -0,0 +1,246 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type trackerTestPlayer struct {
+	mu         sync.Mutex
+	now        time.Time
+	properties map[string]interface{}
+	signals    []chan<- *dbus.Signal
+}
+
+func (tp *trackerTestPlayer) clock() time.Time {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return tp.now
+}
+
+func (tp *trackerTestPlayer) advance(d time.Duration) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.now = tp.now.Add(d)
+}
+
+func (tp *trackerTestPlayer) set(property string, value interface{}) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.properties[property] = value
+}
+
+func (tp *trackerTestPlayer) emit(name string, body ...interface{}) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	for _, ch := range tp.signals {
+		ch <- &dbus.Signal{
+			Sender: ":1.42",
+			Path:   "/org/mpris/MediaPlayer2",
+			Name:   name,
+			Body:   body,
+		}
+	}
+}
+
+// closeSignals closes all signal channels like a closed connection.
+func (tp *trackerTestPlayer) closeSignals() {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	for _, ch := range tp.signals {
+		close(ch)
+	}
+	tp.signals = nil
+}
+
+func (tp *trackerTestPlayer) conn() *dbusConnMock {
+	return &dbusConnMock{
+		ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+			return &dbusBusObjectMock{
+				CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+					return &dbusCallMock{
+						StoreFunc: func(retvalues ...interface{}) error {
+							*retvalues[0].(*string) = ":1.42"
+							return nil
+						},
+					}
+				},
+				GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+					tp.mu.Lock()
+					defer tp.mu.Unlock()
+					v, ok := tp.properties[p]
+					if !ok {
+						return dbus.Variant{}, errors.New("unknown property")
+					}
+					return dbus.MakeVariant(v), nil
+				},
+			}
+		},
+		AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+			return nil
+		},
+		SignalFunc: func(ch chan<- *dbus.Signal) {
+			tp.mu.Lock()
+			defer tp.mu.Unlock()
+			tp.signals = append(tp.signals, ch)
+		},
+		RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+	}
+}
+
+func TestPositionTracker(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tp := &trackerTestPlayer{
+		now: time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+		properties: map[string]interface{}{
+			"org.mpris.MediaPlayer2.Player.PlaybackStatus": "Playing",
+			"org.mpris.MediaPlayer2.Player.Rate":           1.0,
+			"org.mpris.MediaPlayer2.Player.Position":       int64(10000000),
+			"org.mpris.MediaPlayer2.Player.Metadata": map[string]dbus.Variant{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1")),
+				"mpris:length":  dbus.MakeVariant(int64(60000000)),
+			},
+		},
+	}
+
+	tracker, err := newPositionTracker(testCtx, Player{name: "tracker", connection: tp.conn()}, tp.clock)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, tracker.Now())
+
+	// playing
+	tp.advance(5 * time.Second)
+	assert.Equal(t, 15*time.Second, tracker.Now())
+
+	// rate changed
+	tp.set("org.mpris.MediaPlayer2.Player.Position", int64(16000000))
+	tp.emit("org.freedesktop.DBus.Properties.PropertiesChanged", "org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{
+		"Rate": dbus.MakeVariant(2.0),
+	}, []string{})
+	assert.Eventually(t, func() bool {
+		return tracker.Now() == 16*time.Second
+	}, time.Second, time.Millisecond)
+	tp.advance(5 * time.Second)
+	assert.Equal(t, 26*time.Second, tracker.Now())
+
+	// seeked
+	tp.emit("org.mpris.MediaPlayer2.Player.Seeked", int64(40000000))
+	assert.Eventually(t, func() bool {
+		return tracker.Now() == 40*time.Second
+	}, time.Second, time.Millisecond)
+
+	// paused
+	tp.set("org.mpris.MediaPlayer2.Player.Position", int64(41000000))
+	tp.emit("org.freedesktop.DBus.Properties.PropertiesChanged", "org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{
+		"PlaybackStatus": dbus.MakeVariant("Paused"),
+	}, []string{})
+	assert.Eventually(t, func() bool {
+		return tracker.Now() == 41*time.Second
+	}, time.Second, time.Millisecond)
+	tp.advance(10 * time.Second)
+	assert.Equal(t, 41*time.Second, tracker.Now())
+
+	// playing beyond the end of the track
+	tp.emit("org.freedesktop.DBus.Properties.PropertiesChanged", "org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{
+		"PlaybackStatus": dbus.MakeVariant("Playing"),
+	}, []string{})
+	assert.Eventually(t, func() bool {
+		tp.advance(time.Second)
+		return tracker.Now() == time.Minute
+	}, time.Second, time.Millisecond)
+
+	// track changed without position
+	tp.mu.Lock()
+	delete(tp.properties, "org.mpris.MediaPlayer2.Player.Position")
+	tp.mu.Unlock()
+	tp.emit("org.freedesktop.DBus.Properties.PropertiesChanged", "org.mpris.MediaPlayer2.Player", map[string]dbus.Variant{
+		"Metadata": dbus.MakeVariant(map[string]dbus.Variant{
+			"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/2")),
+			"mpris:length":  dbus.MakeVariant(int64(120000000)),
+		}),
+		"Rate": dbus.MakeVariant(1.0),
+	}, []string{})
+	assert.Eventually(t, func() bool {
+		return tracker.Now() == 0
+	}, time.Second, time.Millisecond)
+	tp.advance(90 * time.Second)
+	assert.Equal(t, 90*time.Second, tracker.Now())
+
+	// ticker
+	ticks := tracker.Ticker(testCtx, time.Millisecond)
+	assert.Equal(t, 90*time.Second, <-ticks)
+
+	cancel()
+	for range ticks { // wait until closed
+	}
+}
+
+func TestPositionTracker_Stop(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(tracker *PositionTracker, tp *trackerTestPlayer)
+	}{
+		{
+			name: "stopped",
+			stop: func(tracker *PositionTracker, _ *trackerTestPlayer) { tracker.Stop() },
+		}, {
+			name: "connection closed",
+			stop: func(_ *PositionTracker, tp *trackerTestPlayer) { tp.closeSignals() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			tp := &trackerTestPlayer{
+				properties: map[string]interface{}{
+					"org.mpris.MediaPlayer2.Player.PlaybackStatus": "Playing",
+					"org.mpris.MediaPlayer2.Player.Rate":           1.0,
+					"org.mpris.MediaPlayer2.Player.Metadata":       map[string]dbus.Variant{},
+					"org.mpris.MediaPlayer2.Player.Position":       int64(10000000),
+				},
+			}
+			tracker, err := newPositionTracker(testCtx, Player{name: "tracker", connection: tp.conn()}, tp.clock)
+			require.NoError(t, err)
+			ticks := tracker.Ticker(testCtx, time.Millisecond)
+
+			tt.stop(tracker, tp)
+
+			select {
+			case <-tracker.Done():
+			case <-testCtx.Done():
+				t.Fatal("tracker has not been stopped")
+			}
+			for range ticks { // wait until closed
+			}
+			tp.advance(5 * time.Second)
+			assert.Equal(t, 15*time.Second, tracker.Now())
+		})
+	}
+}
+
+func TestNewPositionTracker_Error(t *testing.T) {
+	tp := &trackerTestPlayer{
+		properties: map[string]interface{}{
+			"org.mpris.MediaPlayer2.Player.PlaybackStatus": "Playing",
+			"org.mpris.MediaPlayer2.Player.Rate":           1.0,
+			"org.mpris.MediaPlayer2.Player.Metadata":       map[string]dbus.Variant{},
+		},
+	}
+
+	_, err := NewPositionTracker(context.Background(), Player{name: "tracker", connection: tp.conn()})
+	assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.Player.Position\": unknown property")
+}
@@ -0,0 +1,250 @@
+package mpris
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	trackListInterface               = "org.mpris.MediaPlayer2.TrackList"
+	trackListGetTracksMetadataMethod = trackListInterface + ".GetTracksMetadata"
+	trackListAddTrackMethod          = trackListInterface + ".AddTrack"
+	trackListRemoveTrackMethod       = trackListInterface + ".RemoveTrack"
+	trackListGoToMethod              = trackListInterface + ".GoTo"
+	trackListTracksProperty          = trackListInterface + ".Tracks"
+	trackListCanEditTracksProperty   = trackListInterface + ".CanEditTracks"
+	signalNameTrackListReplaced      = trackListInterface + ".TrackListReplaced"
+	signalNameTrackAdded             = trackListInterface + ".TrackAdded"
+	signalNameTrackRemoved           = trackListInterface + ".TrackRemoved"
+	signalNameTrackMetadataChanged   = trackListInterface + ".TrackMetadataChanged"
+)
+
+// NoTrack is a special track id which indicates "no track".
+// It can be used with TrackList.AddTrack to insert a track at the beginning of the tracklist.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Mapping:Track_Id
+const NoTrack dbus.ObjectPath = "/org/mpris/MediaPlayer2/TrackList/NoTrack"
+
+// TrackList is an implementation of dbus org.mpris.MediaPlayer2.TrackList. see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html
+// Use Player.TrackList to get the TrackList of a Player. It is only available when Player.HasTrackList is true.
+// TrackList shares the dbus connection of its Player, closing the Player also closes the TrackList.
+type TrackList struct {
+	player Player
+}
+
+// TrackList returns the TrackList of the player.
+// The TrackList interface is only implemented by the media player when HasTrackList is true.
+func (p Player) TrackList() TrackList {
+	return TrackList{
+		player: p,
+	}
+}
+
+// GetTracksMetadata gets all the metadata available for a set of tracks.
+// Parameters:
+// - trackIDs (The list of track ids for which metadata is requested.)
+// Each set of metadata must have a "mpris:trackid" entry at the very least, which contains a string that uniquely
+// identifies this track within the scope of the tracklist.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Method:GetTracksMetadata
+func (t TrackList) GetTracksMetadata(trackIDs []dbus.ObjectPath) ([]Metadata, error) {
+	return t.GetTracksMetadataContext(context.Background(), trackIDs)
+}
+
+// GetTracksMetadataContext is like GetTracksMetadata but uses the given context for the dbus call.
+func (t TrackList) GetTracksMetadataContext(ctx context.Context, trackIDs []dbus.ObjectPath) ([]Metadata, error) {
+	var mds []map[string]dbus.Variant
+	err := t.player.callAndStore(ctx, trackListGetTracksMetadataMethod, []interface{}{trackIDs}, &mds)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]Metadata, len(mds))
+	for i, md := range mds {
+		metadata[i] = md
+	}
+
+	return metadata, nil
+}
+
+// AddTrack adds a URI in the TrackList.
+// Parameters:
+// - uri (The uri of the item to add. Its uri scheme should be an element of the org.mpris.MediaPlayer2.SupportedUriSchemes property and the mime-type should match one of the elements of the org.mpris.MediaPlayer2.SupportedMimeTypes)
+// - afterTrack (The identifier of the track after which the new item should be inserted. The path NoTrack indicates that the track should be inserted at the start of the track list.)
+// - setAsCurrent (Whether the newly inserted track should be considered as the current track. Setting this to true has the same effect as calling GoTo afterwards.)
+// If the CanEditTracks property is false, this has no effect.
+// Note: Clients should not assume that the track has been added at the time when this method returns. They should wait
+// for a TrackAdded (or TrackListReplaced) signal.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Method:AddTrack
+func (t TrackList) AddTrack(uri string, afterTrack dbus.ObjectPath, setAsCurrent bool) error {
+	return t.AddTrackContext(context.Background(), uri, afterTrack, setAsCurrent)
+}
+
+// AddTrackContext is like AddTrack but uses the given context for the dbus call.
+func (t TrackList) AddTrackContext(ctx context.Context, uri string, afterTrack dbus.ObjectPath, setAsCurrent bool) error {
+	return t.player.call(ctx, trackListAddTrackMethod, uri, afterTrack, setAsCurrent)
+}
+
+// RemoveTrack removes an item from the TrackList.
+// Parameters:
+// - trackID (Identifier of the track to be removed. NoTrack is not a valid value for this argument.)
+// If the track is not part of this tracklist, this has no effect.
+// If the CanEditTracks property is false, this has no effect.
+// Note: Clients should not assume that the track has been removed at the time when this method returns. They should
+// wait for a TrackRemoved (or TrackListReplaced) signal.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Method:RemoveTrack
+func (t TrackList) RemoveTrack(trackID dbus.ObjectPath) error {
+	return t.RemoveTrackContext(context.Background(), trackID)
+}
+
+// RemoveTrackContext is like RemoveTrack but uses the given context for the dbus call.
+func (t TrackList) RemoveTrackContext(ctx context.Context, trackID dbus.ObjectPath) error {
+	return t.player.call(ctx, trackListRemoveTrackMethod, trackID)
+}
+
+// GoTo skips to the specified TrackId.
+// Parameters:
+// - trackID (Identifier of the track to skip to. NoTrack is not a valid value for this argument.)
+// If the track is not part of this tracklist, this has no effect.
+// If this object is not /org/mpris/MediaPlayer2, the current TrackList's tracks should be replaced with the contents of
+// this TrackList, and the TrackListReplaced signal should be fired from /org/mpris/MediaPlayer2.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Method:GoTo
+func (t TrackList) GoTo(trackID dbus.ObjectPath) error {
+	return t.GoToContext(context.Background(), trackID)
+}
+
+// GoToContext is like GoTo but uses the given context for the dbus call.
+func (t TrackList) GoToContext(ctx context.Context, trackID dbus.ObjectPath) error {
+	return t.player.call(ctx, trackListGoToMethod, trackID)
+}
+
+// Tracks returns an array which contains the identifier of each track in the tracklist, in order.
+// The org.freedesktop.DBus.Properties.PropertiesChanged signal is emitted every time this property changes, but the
+// signal message does not contain the new value. Client implementations should rather rely on the TrackAdded,
+// TrackRemoved and TrackListReplaced signals to keep their representation of the tracklist up to date.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Property:Tracks
+func (t TrackList) Tracks() ([]dbus.ObjectPath, error) {
+	return t.TracksContext(context.Background())
+}
+
+// TracksContext is like Tracks but uses the given context for the dbus call.
+func (t TrackList) TracksContext(ctx context.Context) ([]dbus.ObjectPath, error) {
+	v, err := t.player.getProperty(ctx, trackListTracksProperty)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue[[]dbus.ObjectPath](trackListTracksProperty, v)
+}
+
+// CanEditTracks returns true whether tracks can be added to and removed from the tracklist (AddTrack, RemoveTrack).
+// If false, calling AddTrack or RemoveTrack will have no effect, and may raise an error.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Property:CanEditTracks
+func (t TrackList) CanEditTracks() (bool, error) {
+	return t.CanEditTracksContext(context.Background())
+}
+
+// CanEditTracksContext is like CanEditTracks but uses the given context for the dbus call.
+func (t TrackList) CanEditTracksContext(ctx context.Context) (bool, error) {
+	v, err := t.player.getProperty(ctx, trackListCanEditTracksProperty)
+	if err != nil {
+		return false, err
+	}
+	return decodeValue[bool](trackListCanEditTracksProperty, v)
+}
+
+// TrackListEvent is emitted by TrackList.Events whenever the tracklist changed. It is one of TrackListReplaced,
+// TrackAdded, TrackRemoved or TrackMetadataChanged.
+type TrackListEvent interface {
+	trackListEvent()
+}
+
+// TrackListReplaced indicates that the entire tracklist has been replaced.
+// It is left up to the implementation to decide when a change to the track list is invasive enough that this signal
+// should be emitted instead of a series of TrackAdded and TrackRemoved signals.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Signal:TrackListReplaced
+type TrackListReplaced struct {
+	// Tracks contains the new content of the tracklist.
+	Tracks []dbus.ObjectPath
+	// CurrentTrack is the identifier of the track to be considered as current. NoTrack indicates that there is no
+	// current track.
+	CurrentTrack dbus.ObjectPath
+}
+
+// TrackAdded indicates that a track has been added to the track list.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Signal:TrackAdded
+type TrackAdded struct {
+	// Metadata of the new track. This must include a mpris:trackid entry.
+	Metadata Metadata
+	// AfterTrack is the identifier of the track after which the new track was inserted. NoTrack indicates that the
+	// track was inserted at the start of the track list.
+	AfterTrack dbus.ObjectPath
+}
+
+// TrackRemoved indicates that a track has been removed from the track list.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Signal:TrackRemoved
+type TrackRemoved struct {
+	// TrackID is the identifier of the track being removed.
+	TrackID dbus.ObjectPath
+}
+
+// TrackMetadataChanged indicates that the metadata of a track in the tracklist has changed. This may indicate that a
+// track has been replaced, in which case the mpris:trackid metadata entry is different from TrackID.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Signal:TrackMetadataChanged
+type TrackMetadataChanged struct {
+	// TrackID is the id of the track which metadata has changed.
+	TrackID dbus.ObjectPath
+	// Metadata contains the new metadata.
+	Metadata Metadata
+}
+
+func (TrackListReplaced) trackListEvent()    {}
+func (TrackAdded) trackListEvent()           {}
+func (TrackRemoved) trackListEvent()         {}
+func (TrackMetadataChanged) trackListEvent() {}
+
+// Events emits a TrackListEvent for every TrackListReplaced, TrackAdded, TrackRemoved and TrackMetadataChanged signal
+// of the tracklist until the given context is done. The returned channel will be closed afterwards.
+// The events are emitted in order of their signals, so they can be used to keep a local copy of Tracks up to date.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Track_List_Interface.html#Signals
+func (t TrackList) Events(ctx context.Context) (<-chan TrackListEvent, error) {
+	events := make(chan TrackListEvent)
+	err := t.player.subscribe(ctx, trackListInterface, "", func(sig *dbus.Signal) {
+		event, ok := parseTrackListSignal(sig)
+		if !ok {
+			return
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}, func() {
+		close(events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func parseTrackListSignal(sig *dbus.Signal) (TrackListEvent, bool) {
+	switch {
+	case sig.Name == signalNameTrackListReplaced && len(sig.Body) == 2:
+		tracks, okTracks := sig.Body[0].([]dbus.ObjectPath)
+		currentTrack, okCurrentTrack := sig.Body[1].(dbus.ObjectPath)
+		return TrackListReplaced{Tracks: tracks, CurrentTrack: currentTrack}, okTracks && okCurrentTrack
+	case sig.Name == signalNameTrackAdded && len(sig.Body) == 2:
+		md, okMetadata := sig.Body[0].(map[string]dbus.Variant)
+		afterTrack, okAfterTrack := sig.Body[1].(dbus.ObjectPath)
+		return TrackAdded{Metadata: md, AfterTrack: afterTrack}, okMetadata && okAfterTrack
+	case sig.Name == signalNameTrackRemoved && len(sig.Body) == 1:
+		trackID, ok := sig.Body[0].(dbus.ObjectPath)
+		return TrackRemoved{TrackID: trackID}, ok
+	case sig.Name == signalNameTrackMetadataChanged && len(sig.Body) == 2:
+		trackID, okTrackID := sig.Body[0].(dbus.ObjectPath)
+		md, okMetadata := sig.Body[1].(map[string]dbus.Variant)
+		return TrackMetadataChanged{TrackID: trackID, Metadata: md}, okTrackID && okMetadata
+	}
+
+	return nil, false // irrelevant or invalid signal
+}
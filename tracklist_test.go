@@ -0,0 +1,462 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayer_TrackList(t *testing.T) {
+	p := Player{name: "tracklist", connection: &dbusConnMock{}}
+
+	assert.Equal(t, TrackList{player: p}, p.TrackList())
+}
+
+func TestTrackList_Methods(t *testing.T) {
+	tests := []struct {
+		name           string
+		storeErr       error
+		action         func(tl TrackList) error
+		expectedMethod string
+		expectedArgs   []interface{}
+		expectedErr    string
+	}{
+		{
+			name: "AddTrack",
+			action: func(tl TrackList) error {
+				return tl.AddTrack("file://my/uri", "/my/track", true)
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.AddTrack",
+			expectedArgs:   []interface{}{"file://my/uri", dbus.ObjectPath("/my/track"), true},
+		}, {
+			name:     "AddTrack error",
+			storeErr: errors.New("nope"),
+			action: func(tl TrackList) error {
+				return tl.AddTrack("file://my/uri", NoTrack, false)
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.AddTrack",
+			expectedArgs:   []interface{}{"file://my/uri", dbus.ObjectPath("/org/mpris/MediaPlayer2/TrackList/NoTrack"), false},
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.TrackList.AddTrack\": nope",
+		}, {
+			name: "RemoveTrack",
+			action: func(tl TrackList) error {
+				return tl.RemoveTrack("/my/track")
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.RemoveTrack",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/my/track")},
+		}, {
+			name:     "RemoveTrack error",
+			storeErr: errors.New("nope"),
+			action: func(tl TrackList) error {
+				return tl.RemoveTrack("/my/track")
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.RemoveTrack",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/my/track")},
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.TrackList.RemoveTrack\": nope",
+		}, {
+			name: "GoTo",
+			action: func(tl TrackList) error {
+				return tl.GoTo("/my/track")
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.GoTo",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/my/track")},
+		}, {
+			name:     "GoTo error",
+			storeErr: errors.New("nope"),
+			action: func(tl TrackList) error {
+				return tl.GoTo("/my/track")
+			},
+			expectedMethod: "org.mpris.MediaPlayer2.TrackList.GoTo",
+			expectedArgs:   []interface{}{dbus.ObjectPath("/my/track")},
+			expectedErr:    "failed to call method \"org.mpris.MediaPlayer2.TrackList.GoTo\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenDest string
+			var givenPath dbus.ObjectPath
+			var givenMethod string
+			var givenArgs []interface{}
+
+			err := tt.action(Player{
+				name: "tracklist",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						givenDest = dest
+						givenPath = path
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(_ ...interface{}) error {
+										return tt.storeErr
+									},
+								}
+							},
+						}
+					},
+				},
+			}.TrackList())
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, "tracklist", givenDest, "given dest is not as expected")
+			assert.Equal(t, dbus.ObjectPath("/org/mpris/MediaPlayer2"), givenPath, "given path is not as expected")
+			assert.Equal(t, tt.expectedMethod, givenMethod, "given method is not as expected")
+			assert.EqualValues(t, tt.expectedArgs, givenArgs, "given args is not as expected")
+		})
+	}
+}
+
+func TestTrackList_GetTracksMetadata(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenMetadata    []map[string]dbus.Variant
+		storeErr         error
+		expectedMetadata []Metadata
+		expectedErr      string
+	}{
+		{
+			name: "happycase",
+			givenMetadata: []map[string]dbus.Variant{
+				{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1"))},
+				{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/2"))},
+			},
+			expectedMetadata: []Metadata{
+				{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/1"))},
+				{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/2"))},
+			},
+		}, {
+			name:        "error",
+			storeErr:    errors.New("nope"),
+			expectedErr: "failed to call method \"org.mpris.MediaPlayer2.TrackList.GetTracksMetadata\": nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var givenMethod string
+			var givenArgs []interface{}
+
+			md, err := Player{
+				name: "tracklist",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							CallWithContextFunc: func(_ context.Context, method string, flags dbus.Flags, args ...interface{}) dbusCall {
+								givenMethod = method
+								givenArgs = args
+								return &dbusCallMock{
+									StoreFunc: func(retvalues ...interface{}) error {
+										require.Len(t, retvalues, 1)
+										*retvalues[0].(*[]map[string]dbus.Variant) = tt.givenMetadata
+										return tt.storeErr
+									},
+								}
+							},
+						}
+					},
+				},
+			}.TrackList().GetTracksMetadata([]dbus.ObjectPath{"/track/1", "/track/2"})
+
+			assert.Equal(t, tt.expectedErr, msgOrEmpty(err))
+			assert.Equal(t, tt.expectedMetadata, md)
+			assert.Equal(t, "org.mpris.MediaPlayer2.TrackList.GetTracksMetadata", givenMethod)
+			assert.Equal(t, []interface{}{[]dbus.ObjectPath{"/track/1", "/track/2"}}, givenArgs)
+		})
+	}
+}
+
+func TestTrackList_GetProperties(t *testing.T) {
+	tests := []struct {
+		name           string
+		callVariant    dbus.Variant
+		callError      error
+		runAndValidate func(t *testing.T, tl TrackList)
+		expectedKey    string
+	}{
+		{
+			name:        "Tracks",
+			callVariant: dbus.MakeVariant([]dbus.ObjectPath{"/track/1", "/track/2"}),
+			runAndValidate: func(t *testing.T, tl TrackList) {
+				tracks, err := tl.Tracks()
+				assert.NoError(t, err)
+				assert.Equal(t, []dbus.ObjectPath{"/track/1", "/track/2"}, tracks)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.TrackList.Tracks",
+		}, {
+			name:      "Tracks error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, tl TrackList) {
+				_, err := tl.Tracks()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.TrackList.Tracks\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.TrackList.Tracks",
+		}, {
+			name:        "CanEditTracks",
+			callVariant: dbus.MakeVariant(true),
+			runAndValidate: func(t *testing.T, tl TrackList) {
+				b, err := tl.CanEditTracks()
+				assert.NoError(t, err)
+				assert.True(t, b)
+			},
+			expectedKey: "org.mpris.MediaPlayer2.TrackList.CanEditTracks",
+		}, {
+			name:      "CanEditTracks error",
+			callError: errors.New("nope"),
+			runAndValidate: func(t *testing.T, tl TrackList) {
+				_, err := tl.CanEditTracks()
+				assert.EqualError(t, err, "failed to get property \"org.mpris.MediaPlayer2.TrackList.CanEditTracks\": nope")
+			},
+			expectedKey: "org.mpris.MediaPlayer2.TrackList.CanEditTracks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calledKey string
+
+			tt.runAndValidate(t, Player{
+				name: "tracklist",
+				connection: &dbusConnMock{
+					ObjectFunc: func(dest string, path dbus.ObjectPath) dbusBusObject {
+						return &dbusBusObjectMock{
+							GetPropertyWithContextFunc: func(_ context.Context, p string) (dbus.Variant, error) {
+								calledKey = p
+								return tt.callVariant, tt.callError
+							},
+						}
+					},
+				},
+			}.TrackList())
+
+			assert.Equal(t, tt.expectedKey, calledKey, "called key is not as expected")
+		})
+	}
+}
+
+func TestTrackList_Events(t *testing.T) {
+	tests := []struct {
+		name string
+
+		givenSignals []*dbus.Signal
+
+		nameOwnerErr                    error
+		addMatchSignalErr               error
+		expectedAddMatchSignalCallCount int
+		expectedSignalCallCount         int
+
+		expectedErr    string
+		expectedEvents []TrackListEvent
+	}{
+		{
+			name:                            "happycase",
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         1,
+			givenSignals: []*dbus.Signal{
+				{
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackListReplaced",
+					Body:   []interface{}{[]dbus.ObjectPath{"/track/1", "/track/2"}, dbus.ObjectPath("/track/1")},
+				}, { // other sender
+					Sender: ":1.8",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackRemoved",
+					Body:   []interface{}{dbus.ObjectPath("/track/8")},
+				}, { // other path
+					Sender: ":1.7",
+					Path:   "/other",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackRemoved",
+					Body:   []interface{}{dbus.ObjectPath("/track/8")},
+				}, { // other interface
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.Player.Seeked",
+					Body:   []interface{}{int64(1)},
+				}, {
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackAdded",
+					Body: []interface{}{
+						map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/3"))},
+						dbus.ObjectPath("/track/2"),
+					},
+				}, { // invalid body type
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackRemoved",
+					Body:   []interface{}{"/track/1"},
+				}, { // missing body infos
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackMetadataChanged",
+					Body:   []interface{}{dbus.ObjectPath("/track/1")},
+				}, {
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackRemoved",
+					Body:   []interface{}{dbus.ObjectPath("/track/1")},
+				}, {
+					Sender: ":1.7",
+					Path:   "/org/mpris/MediaPlayer2",
+					Name:   "org.mpris.MediaPlayer2.TrackList.TrackMetadataChanged",
+					Body: []interface{}{
+						dbus.ObjectPath("/track/2"),
+						map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/4"))},
+					},
+				},
+			},
+			expectedEvents: []TrackListEvent{
+				TrackListReplaced{Tracks: []dbus.ObjectPath{"/track/1", "/track/2"}, CurrentTrack: "/track/1"},
+				TrackAdded{
+					Metadata:   Metadata{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/3"))},
+					AfterTrack: "/track/2",
+				},
+				TrackRemoved{TrackID: "/track/1"},
+				TrackMetadataChanged{
+					TrackID:  "/track/2",
+					Metadata: Metadata{"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/track/4"))},
+				},
+			},
+		}, {
+			name:                            "name owner error",
+			nameOwnerErr:                    errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 0,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to get owner of \"tracklist\": unexpected error",
+		}, {
+			name:                            "add match signal error",
+			addMatchSignalErr:               errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to add signal match option: unexpected error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			mock := &dbusConnMock{
+				ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+					return &dbusBusObjectMock{
+						CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+							return &dbusCallMock{
+								StoreFunc: func(retvalues ...interface{}) error {
+									*retvalues[0].(*string) = ":1.7"
+									return tt.nameOwnerErr
+								},
+							}
+						},
+					}
+				},
+				AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return tt.addMatchSignalErr
+				},
+				RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return nil
+				},
+				SignalFunc: func(ch chan<- *dbus.Signal) {
+					go func() {
+						for _, sig := range tt.givenSignals {
+							ch <- sig
+						}
+					}()
+				},
+				RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+			}
+
+			events, err := Player{name: "tracklist", connection: mock}.TrackList().Events(testCtx)
+			require.Equal(t, tt.expectedErr, msgOrEmpty(err))
+
+			var collectedEvents []TrackListEvent
+			if events != nil {
+				for len(collectedEvents) < len(tt.expectedEvents) {
+					e, ok := <-events
+					if !ok {
+						break
+					}
+					collectedEvents = append(collectedEvents, e)
+				}
+				cancel()
+				for range events { // wait until closed
+				}
+
+				assert.Equal(t, []dbus.MatchOption{
+					dbus.WithMatchSender("tracklist"),
+					dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
+					dbus.WithMatchInterface("org.mpris.MediaPlayer2.TrackList"),
+				}, mock.AddMatchSignalCalls()[0].MatchOptions)
+				assert.Equal(t, 1, len(mock.RemoveMatchSignalCalls()))
+				assert.Equal(t, 1, len(mock.RemoveSignalCalls()))
+			}
+			assert.EqualValues(t, tt.expectedEvents, collectedEvents)
+			assert.Equal(t, tt.expectedAddMatchSignalCallCount, len(mock.AddMatchSignalCalls()))
+			assert.Equal(t, tt.expectedSignalCallCount, len(mock.SignalCalls()))
+		})
+	}
+}
+
+func TestTrackList_EventsSlowConsumer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	signals := make(chan chan<- *dbus.Signal, 1)
+	mock := &dbusConnMock{
+		ObjectFunc: func(_ string, _ dbus.ObjectPath) dbusBusObject {
+			return &dbusBusObjectMock{
+				CallWithContextFunc: func(_ context.Context, _ string, _ dbus.Flags, _ ...interface{}) dbusCall {
+					return &dbusCallMock{
+						StoreFunc: func(retvalues ...interface{}) error {
+							*retvalues[0].(*string) = ":1.7"
+							return nil
+						},
+					}
+				},
+			}
+		},
+		AddMatchSignalFunc:    func(_ ...dbus.MatchOption) error { return nil },
+		RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error { return nil },
+		SignalFunc:            func(ch chan<- *dbus.Signal) { signals <- ch },
+		RemoveSignalFunc:      func(_ chan<- *dbus.Signal) {},
+	}
+
+	events, err := Player{name: "tracklist", connection: mock}.TrackList().Events(ctx)
+	require.NoError(t, err)
+
+	// the connection delivers signals out of order once the channel is full, so it has to be drained immediately
+	ch := <-signals
+	const count = 200
+	for i := 0; i < count; i++ {
+		select {
+		case ch <- &dbus.Signal{
+			Sender: ":1.7",
+			Path:   "/org/mpris/MediaPlayer2",
+			Name:   "org.mpris.MediaPlayer2.TrackList.TrackRemoved",
+			Body:   []interface{}{dbus.ObjectPath(fmt.Sprintf("/track/%d", i))},
+		}:
+		case <-time.After(time.Second):
+			t.Fatalf("signal channel is full after %d signals", i)
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		time.Sleep(100 * time.Microsecond) // slow consumer
+		e := <-events
+		require.Equal(t, TrackRemoved{TrackID: dbus.ObjectPath(fmt.Sprintf("/track/%d", i))}, e)
+	}
+
+	cancel()
+	for range events { // wait until closed
+	}
+	assert.Equal(t, 1, len(mock.RemoveSignalCalls()))
+}
@@ -3,13 +3,14 @@ package mpris
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/godbus/dbus/v5"
 )
 
-const timeFormat = "2006-01-02T15:04-07:00"
-
 // ErrTypeNotParsable indicates, that the given type is not parable.
 var ErrTypeNotParsable = errors.New("the given type is not as expected")
 
@@ -37,6 +38,43 @@ const (
 	LoopStatusPlaylist LoopStatus = "Playlist"
 )
 
+// PlaylistOrdering specifies the ordering of returned playlists.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Enum:Playlist_Ordering
+type PlaylistOrdering string
+
+const (
+	// PlaylistOrderingAlphabetical represents the playlist ordering "Alphabetical" (by name, ascending).
+	PlaylistOrderingAlphabetical PlaylistOrdering = "Alphabetical"
+	// PlaylistOrderingCreationDate represents the playlist ordering "Created" (by creation date, oldest first).
+	PlaylistOrderingCreationDate PlaylistOrdering = "Created"
+	// PlaylistOrderingModifiedDate represents the playlist ordering "Modified" (by last modified date, oldest first).
+	PlaylistOrderingModifiedDate PlaylistOrdering = "Modified"
+	// PlaylistOrderingLastPlayDate represents the playlist ordering "Played" (by date last played, oldest first).
+	PlaylistOrderingLastPlayDate PlaylistOrdering = "Played"
+	// PlaylistOrderingUserDefined represents the playlist ordering "User" (a user-defined ordering).
+	PlaylistOrderingUserDefined PlaylistOrdering = "User"
+)
+
+// Playlist represents a playlist.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Struct:Playlist
+type Playlist struct {
+	// ID is a unique identifier for the playlist. This should remain the same if the playlist is renamed.
+	ID dbus.ObjectPath
+	// Name is the name of the playlist, typically given by the user.
+	Name string
+	// Icon is the URI of an (optional) icon.
+	Icon string
+}
+
+// MaybePlaylist represents a playlist which may not be present.
+// see: https://specifications.freedesktop.org/mpris-spec/2.2/Playlists_Interface.html#Struct:Maybe_Playlist
+type MaybePlaylist struct {
+	// Valid is true if Playlist is present.
+	Valid bool
+	// Playlist is only meaningful if Valid is true.
+	Playlist Playlist
+}
+
 // Metadata represents the mpris-metadata
 // see: https://www.freedesktop.org/wiki/Specifications/mpris-spec/metadata/
 type Metadata map[string]dbus.Variant
@@ -56,14 +94,15 @@ func (md Metadata) MPRISTrackID() (dbus.ObjectPath, error) {
 	return v, nil
 }
 
-// MPRISLength returns the duration of the track in microseconds.
+// MPRISLength returns the duration of the track in microseconds. All integer types are accepted as media players
+// send it as int64, uint64 or int32.
 func (md Metadata) MPRISLength() (int64, error) {
 	vl := md["mpris:length"].Value()
 	if vl == nil {
 		return 0, nil
 	}
 
-	v, ok := vl.(int64)
+	v, ok := toInt64(vl)
 	if !ok {
 		return 0, fmt.Errorf("%T could not be parsed to int64: %w", vl, ErrTypeNotParsable)
 	}
@@ -71,6 +110,16 @@ func (md Metadata) MPRISLength() (int64, error) {
 	return v, nil
 }
 
+// Length returns the duration of the track.
+func (md Metadata) Length() (time.Duration, error) {
+	length, err := md.MPRISLength()
+	if err != nil {
+		return 0, err
+	}
+
+	return microseconds(length), nil
+}
+
 // MPRISArtURL returns the location of an image representing the track or album. Clients should not assume this will
 // continue to exist when the media player stops giving out the URL.
 func (md Metadata) MPRISArtURL() (string, error) {
@@ -154,12 +203,7 @@ func (md Metadata) XESAMAudioBPM() (int, error) {
 		return 0, nil
 	}
 
-	v, ok := va.(int)
-	if !ok {
-		return 0, fmt.Errorf("%T could not be parsed to int: %w", va, ErrTypeNotParsable)
-	}
-
-	return v, nil
+	return metadataInt(va)
 }
 
 // XESAMAutoRating returns an automatically-generated rating, based on things such as how often it has been played.
@@ -205,24 +249,26 @@ func (md Metadata) XESAMComposer() ([]string, error) {
 	return v, nil
 }
 
-// XESAMContentCreated returns when the track was created. Usually only the year component will be useful.
-func (md Metadata) XESAMContentCreated() (time.Time, error) {
-	vc := md["xesam:contentCreated"].Value()
-	if vc == nil {
-		return time.Time{}, nil
+// Date returns the date of the field with the given key (e.g. "xesam:lastUsed") and its precision. The date is parsed
+// via ParseDate. If the field is not present, the zero time is returned.
+func (md Metadata) Date(key string) (time.Time, DatePrecision, error) {
+	vd := md[key].Value()
+	if vd == nil {
+		return time.Time{}, 0, nil
 	}
 
-	vs, ok := vc.(string)
+	vs, ok := vd.(string)
 	if !ok {
-		return time.Time{}, fmt.Errorf("%T could not be parsed to string: %w", vc, ErrTypeNotParsable)
+		return time.Time{}, 0, fmt.Errorf("%T could not be parsed to string: %w", vd, ErrTypeNotParsable)
 	}
 
-	t, err := time.Parse(timeFormat, vs)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("cound not parse time: %s: %w", err, ErrTypeNotParsable)
-	}
+	return ParseDate(vs)
+}
 
-	return t, nil
+// XESAMContentCreated returns when the track was created. Usually only the year component will be useful.
+func (md Metadata) XESAMContentCreated() (time.Time, error) {
+	t, _, err := md.Date("xesam:contentCreated")
+	return t, err
 }
 
 // XESAMDiscNumber returns the disc number on the album that this track is from.
@@ -232,31 +278,13 @@ func (md Metadata) XESAMDiscNumber() (int, error) {
 		return 0, nil
 	}
 
-	v, ok := vn.(int)
-	if !ok {
-		return 0, fmt.Errorf("%T could not be parsed to int: %w", vn, ErrTypeNotParsable)
-	}
-	return v, nil
+	return metadataInt(vn)
 }
 
 // XESAMFirstUsed returns when the track was first played.
 func (md Metadata) XESAMFirstUsed() (time.Time, error) {
-	vu := md["xesam:firstUsed"].Value()
-	if vu == nil {
-		return time.Time{}, nil
-	}
-
-	vs, ok := vu.(string)
-	if !ok {
-		return time.Time{}, fmt.Errorf("%T could not be parsed to string: %w", vu, ErrTypeNotParsable)
-	}
-
-	t, err := time.Parse(timeFormat, vs)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("cound not parse time: %s: %w", err, ErrTypeNotParsable)
-	}
-
-	return t, nil
+	t, _, err := md.Date("xesam:firstUsed")
+	return t, err
 }
 
 // XESAMGenre returns the genre(s) of the track.
@@ -275,22 +303,8 @@ func (md Metadata) XESAMGenre() ([]string, error) {
 
 // XESAMLastUsed returns when the track was last played.
 func (md Metadata) XESAMLastUsed() (time.Time, error) {
-	vu := md["xesam:lastUsed"].Value()
-	if vu == nil {
-		return time.Time{}, nil
-	}
-
-	vs, ok := vu.(string)
-	if !ok {
-		return time.Time{}, fmt.Errorf("%T could not be parsed to string: %w", vu, ErrTypeNotParsable)
-	}
-
-	t, err := time.Parse(timeFormat, vs)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("cound not parse time: %s: %w", err, ErrTypeNotParsable)
-	}
-
-	return t, nil
+	t, _, err := md.Date("xesam:lastUsed")
+	return t, err
 }
 
 // XESAMLyricist returns the lyricist(s) of the track.
@@ -329,11 +343,7 @@ func (md Metadata) XESAMTrackNumber() (int, error) {
 		return 0, nil
 	}
 
-	v, ok := vn.(int)
-	if !ok {
-		return 0, fmt.Errorf("%T could not be parsed to int: %w", vn, ErrTypeNotParsable)
-	}
-	return v, nil
+	return metadataInt(vn)
 }
 
 // XESAMURL returns the location of the media file.
@@ -358,11 +368,7 @@ func (md Metadata) XESAMUseCount() (int, error) {
 		return 0, nil
 	}
 
-	v, ok := vc.(int)
-	if !ok {
-		return 0, fmt.Errorf("%T could not be parsed to int: %w", vc, ErrTypeNotParsable)
-	}
-	return v, nil
+	return metadataInt(vc)
 }
 
 // XESAMUserRating returns a user-specified rating. This should be in the range 0.0 to 1.0.
@@ -384,3 +390,30 @@ func (md Metadata) Find(key string) (dbus.Variant, bool) {
 	variant, found := md[key]
 	return variant, found
 }
+
+// microseconds converts the given number of microseconds, as used by mpris for positions and lengths, to a
+// time.Duration. Values out of the range of time.Duration (about 292 years) are saturated to not overflow.
+func microseconds(micros int64) time.Duration {
+	switch {
+	case micros > math.MaxInt64/int64(time.Microsecond):
+		return math.MaxInt64
+	case micros < math.MinInt64/int64(time.Microsecond):
+		return math.MinInt64
+	}
+	return time.Duration(micros) * time.Microsecond
+}
+
+// metadataInt converts the value of an integer metadata field to int. Besides int32 (as specified by xesam), all other
+// integer dbus types and strings containing an integer are accepted as some media players send them.
+func metadataInt(value interface{}) (int, error) {
+	i, ok := toInt64(value)
+	if s, isString := value.(string); isString {
+		parsed, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		i, ok = parsed, err == nil
+	}
+	if !ok || i < math.MinInt || i > math.MaxInt {
+		return 0, fmt.Errorf("%T could not be parsed to int: %w", value, ErrTypeNotParsable)
+	}
+
+	return int(i), nil
+}
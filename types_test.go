@@ -2,13 +2,23 @@ package mpris
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// parseMetadata parses the given metadata in GVariant text format (e.g. "{'xesam:trackNumber': <int32 1>}") to have
+// the same types as received from the bus.
+func parseMetadata(t *testing.T, s string) Metadata {
+	v, err := dbus.ParseVariant(s, dbus.ParseSignatureMust("a{sv}"))
+	require.NoError(t, err)
+	return v.Value().(map[string]dbus.Variant)
+}
+
 func TestMetadata_MPRISTrackID(t *testing.T) {
 	var expectedTrackID dbus.ObjectPath
 	var expectedErrorText string
@@ -56,6 +66,33 @@ func TestMetadata_MPRISLength(t *testing.T) {
 	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
 	assert.Equal(t, expectedLength, length, "unexpected length")
 
+	//uint64
+	expectedLength = 42
+	expectedErrorText = "<nil>"
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(uint64(42)),
+	}.MPRISLength()
+	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
+	assert.Equal(t, expectedLength, length, "unexpected length")
+
+	//int32
+	expectedLength = 42
+	expectedErrorText = "<nil>"
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(int32(42)),
+	}.MPRISLength()
+	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
+	assert.Equal(t, expectedLength, length, "unexpected length")
+
+	//uint64 out of range
+	expectedLength = 0
+	expectedErrorText = "uint64 could not be parsed to int64: the given type is not as expected"
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(uint64(math.MaxUint64)),
+	}.MPRISLength()
+	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
+	assert.Equal(t, expectedLength, length, "unexpected length")
+
 	//not present
 	expectedLength = 0
 	expectedErrorText = "<nil>"
@@ -73,6 +110,65 @@ func TestMetadata_MPRISLength(t *testing.T) {
 	assert.Equal(t, expectedLength, length, "unexpected length")
 }
 
+func TestMetadata_Length(t *testing.T) {
+	length, err := Metadata{
+		"mpris:length": dbus.MakeVariant(int64(3723000001)),
+	}.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+2*time.Minute+3*time.Second+time.Microsecond, length, "unexpected length")
+
+	length, err = Metadata{}.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), length, "unexpected length")
+
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(uint64(3723000001)),
+	}.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+2*time.Minute+3*time.Second+time.Microsecond, length, "unexpected length")
+
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(int32(3000000)),
+	}.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, length, "unexpected length")
+
+	length, err = Metadata{
+		"mpris:length": dbus.MakeVariant(int64(math.MaxInt64)),
+	}.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(math.MaxInt64), length, "unexpected length")
+
+	_, err = Metadata{
+		"mpris:length": dbus.MakeVariant("nope"),
+	}.Length()
+	assert.ErrorIs(t, err, ErrTypeNotParsable)
+}
+
+func TestMicroseconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    int64
+		expected time.Duration
+	}{
+		{name: "zero", given: 0, expected: 0},
+		{name: "positive", given: 1500000, expected: 1500 * time.Millisecond},
+		{name: "negative", given: -1500000, expected: -1500 * time.Millisecond},
+		{name: "max", given: math.MaxInt64 / 1000, expected: math.MaxInt64 / 1000 * time.Microsecond},
+		{name: "min", given: math.MinInt64 / 1000, expected: math.MinInt64 / 1000 * time.Microsecond},
+		{name: "overflow", given: math.MaxInt64/1000 + 1, expected: math.MaxInt64},
+		{name: "underflow", given: math.MinInt64/1000 - 1, expected: math.MinInt64},
+		{name: "max int64", given: math.MaxInt64, expected: math.MaxInt64},
+		{name: "min int64", given: math.MinInt64, expected: math.MinInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, microseconds(tt.given))
+		})
+	}
+}
+
 func TestMetadata_MPRISArtURL(t *testing.T) {
 	var expectedArtURL string
 	var expectedErrorText string
@@ -242,9 +338,7 @@ func TestMetadata_XESAMAudioBPM(t *testing.T) {
 	//happycase
 	expectedAudioBPM = 4711
 	expectedErrorText = "<nil>"
-	audioBPM, err = Metadata{
-		"xesam:audioBPM": dbus.MakeVariant(4711),
-	}.XESAMAudioBPM()
+	audioBPM, err = parseMetadata(t, "{'xesam:audioBPM': <int32 4711>}").XESAMAudioBPM()
 	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
 	assert.Equal(t, expectedAudioBPM, audioBPM, "unexpected audioBPM")
 
@@ -394,7 +488,7 @@ func TestMetadata_XESAMContentCreated(t *testing.T) {
 
 	//unexpected date format
 	expectedContentCreated = time.Time{}
-	expectedErrorText = `cound not parse time: parsing time "not a date-time" as "2006-01-02T15:04-07:00": cannot parse "not a date-time" as "2006": the given type is not as expected`
+	expectedErrorText = `could not parse date "not a date-time": the given type is not as expected`
 	contentCreated, err = Metadata{
 		"xesam:contentCreated": dbus.MakeVariant("not a date-time"),
 	}.XESAMContentCreated()
@@ -411,9 +505,7 @@ func TestMetadata_XESAMDiscNumber(t *testing.T) {
 	//happycase
 	expectedDiscNumber = 42
 	expectedErrorText = "<nil>"
-	discNumber, err = Metadata{
-		"xesam:discNumber": dbus.MakeVariant(42),
-	}.XESAMDiscNumber()
+	discNumber, err = parseMetadata(t, "{'xesam:discNumber': <int32 42>}").XESAMDiscNumber()
 	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
 	assert.Equal(t, expectedDiscNumber, discNumber, "unexpected discNumber")
 
@@ -467,7 +559,7 @@ func TestMetadata_XESAMFirstUsed(t *testing.T) {
 
 	//unexpected date format
 	expectedFirstUsed = time.Time{}
-	expectedErrorText = `cound not parse time: parsing time "not a date-time" as "2006-01-02T15:04-07:00": cannot parse "not a date-time" as "2006": the given type is not as expected`
+	expectedErrorText = `could not parse date "not a date-time": the given type is not as expected`
 	firstUsed, err = Metadata{
 		"xesam:firstUsed": dbus.MakeVariant("not a date-time"),
 	}.XESAMFirstUsed()
@@ -540,7 +632,7 @@ func TestMetadata_XESAMLastUsed(t *testing.T) {
 
 	//unexpected date format
 	expectedLastUsed = time.Time{}
-	expectedErrorText = `cound not parse time: parsing time "not a date-time" as "2006-01-02T15:04-07:00": cannot parse "not a date-time" as "2006": the given type is not as expected`
+	expectedErrorText = `could not parse date "not a date-time": the given type is not as expected`
 	lastUsed, err = Metadata{
 		"xesam:lastUsed": dbus.MakeVariant("not a date-time"),
 	}.XESAMLastUsed()
@@ -621,9 +713,7 @@ func TestMetadata_XESAMTrackNumber(t *testing.T) {
 	//happycase
 	expectedTrackNumber = 42
 	expectedErrorText = "<nil>"
-	trackNumber, err = Metadata{
-		"xesam:trackNumber": dbus.MakeVariant(42),
-	}.XESAMTrackNumber()
+	trackNumber, err = parseMetadata(t, "{'xesam:trackNumber': <int32 42>}").XESAMTrackNumber()
 	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
 	assert.Equal(t, expectedTrackNumber, trackNumber, "unexpected trackNumber")
 
@@ -685,9 +775,7 @@ func TestMetadata_XESAMUseCount(t *testing.T) {
 	//happycase
 	expectedUseCount = 42
 	expectedErrorText = "<nil>"
-	useCount, err = Metadata{
-		"xesam:useCount": dbus.MakeVariant(42),
-	}.XESAMUseCount()
+	useCount, err = parseMetadata(t, "{'xesam:useCount': <int32 42>}").XESAMUseCount()
 	assert.Equal(t, expectedErrorText, fmt.Sprint(err), "unexpected error text")
 	assert.Equal(t, expectedUseCount, useCount, "unexpected useCount")
 
@@ -750,3 +838,53 @@ func TestMetadata_Find(t *testing.T) {
 	assert.Equal(t, expectedValue, value)
 
 }
+
+func TestMetadata_IntegerFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenValue    string
+		expectedValue int
+		expectedErr   string
+	}{
+		{name: "int32", givenValue: "<int32 7>", expectedValue: 7},
+		{name: "int64", givenValue: "<int64 7>", expectedValue: 7},
+		{name: "uint32", givenValue: "<uint32 7>", expectedValue: 7},
+		{name: "int16", givenValue: "<int16 -7>", expectedValue: -7},
+		{name: "byte", givenValue: "<byte 7>", expectedValue: 7},
+		{name: "string", givenValue: "<'7'>", expectedValue: 7},
+		{name: "string with spaces", givenValue: "<' 7 '>", expectedValue: 7},
+		{
+			name:        "not numeric string",
+			givenValue:  "<'seven'>",
+			expectedErr: "string could not be parsed to int: the given type is not as expected",
+		}, {
+			name:        "double",
+			givenValue:  "<7.0>",
+			expectedErr: "float64 could not be parsed to int: the given type is not as expected",
+		}, {
+			name:        "uint64 overflow",
+			givenValue:  "<uint64 18446744073709551615>",
+			expectedErr: "uint64 could not be parsed to int: the given type is not as expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := parseMetadata(t, fmt.Sprintf(
+				"{'xesam:audioBPM': %[1]s, 'xesam:discNumber': %[1]s, 'xesam:trackNumber': %[1]s, 'xesam:useCount': %[1]s}",
+				tt.givenValue,
+			))
+
+			for name, accessor := range map[string]func() (int, error){
+				"XESAMAudioBPM":    md.XESAMAudioBPM,
+				"XESAMDiscNumber":  md.XESAMDiscNumber,
+				"XESAMTrackNumber": md.XESAMTrackNumber,
+				"XESAMUseCount":    md.XESAMUseCount,
+			} {
+				v, err := accessor()
+				assert.Equal(t, tt.expectedErr, msgOrEmpty(err), name)
+				assert.Equal(t, tt.expectedValue, v, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package mpris
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusNameOwnerChangedMember = "NameOwnerChanged"
+	signalNameNameOwnerChanged = dbusInterface + "." + dbusNameOwnerChangedMember
+)
+
+// PlayerEvent is emitted by WatchPlayers whenever a media player appears on, disappears from or changes its owner on
+// the bus. It is one of PlayerAdded, PlayerRemoved or PlayerOwnerChanged.
+type PlayerEvent interface {
+	playerEvent()
+}
+
+// PlayerAdded indicates that a media player appeared on the bus.
+type PlayerAdded struct {
+	Handle PlayerHandle
+}
+
+// PlayerRemoved indicates that a media player disappeared from the bus.
+// Handle.UniqueName contains the unique name of the former owner.
+type PlayerRemoved struct {
+	Handle PlayerHandle
+}
+
+// PlayerOwnerChanged indicates that the bus name of a media player has been taken over by another connection.
+// Handle.UniqueName contains the unique name of the new owner.
+type PlayerOwnerChanged struct {
+	Handle        PlayerHandle
+	OldUniqueName string
+}
+
+func (PlayerAdded) playerEvent()        {}
+func (PlayerRemoved) playerEvent()      {}
+func (PlayerOwnerChanged) playerEvent() {}
+
+// WatchPlayers emits a PlayerEvent for every media player which appears on, disappears from or changes its owner on
+// the session-bus until the given context is done. The returned channel will be closed afterwards.
+// Players which are already available are not emitted. Call ListPlayers after WatchPlayers to get them without
+// missing any event in between.
+// see: https://dbus.freedesktop.org/doc/dbus-specification.html#bus-messages-name-owner-changed
+func WatchPlayers(ctx context.Context) (<-chan PlayerEvent, error) {
+	connection, err := dbusSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to session-bus: %w", err)
+	}
+
+	return watchPlayers(ctx, &dbusConnWrapper{conn: connection})
+}
+
+func watchPlayers(ctx context.Context, connection dbusConn) (<-chan PlayerEvent, error) {
+	events := make(chan PlayerEvent)
+	err := subscribe(ctx, connection, []dbus.MatchOption{
+		dbus.WithMatchSender(dbusDestination),
+		dbus.WithMatchObjectPath(dbusObjectPath),
+		dbus.WithMatchInterface(dbusInterface),
+		dbus.WithMatchMember(dbusNameOwnerChangedMember),
+		dbus.WithMatchArg0Namespace(strings.TrimSuffix(busNamePrefix, ".")),
+	}, func(sig *dbus.Signal) {
+		event, ok := parseNameOwnerChanged(sig)
+		if !ok {
+			return
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}, func() {
+		close(events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func parseNameOwnerChanged(sig *dbus.Signal) (PlayerEvent, bool) {
+	if sig.Name != signalNameNameOwnerChanged || // irrelevant signal
+		len(sig.Body) != 3 { // invalid event
+		return nil, false
+	}
+
+	name, okName := sig.Body[0].(string)
+	oldOwner, okOld := sig.Body[1].(string)
+	newOwner, okNew := sig.Body[2].(string)
+	if !okName || !okOld || !okNew || // broken signal
+		!strings.HasPrefix(name, busNamePrefix) { // no media player
+		return nil, false
+	}
+
+	switch {
+	case oldOwner == "" && newOwner != "":
+		return PlayerAdded{Handle: newPlayerHandle(name, newOwner)}, true
+	case oldOwner != "" && newOwner == "":
+		return PlayerRemoved{Handle: newPlayerHandle(name, oldOwner)}, true
+	case oldOwner != "" && newOwner != "":
+		return PlayerOwnerChanged{Handle: newPlayerHandle(name, newOwner), OldUniqueName: oldOwner}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,129 @@
+package mpris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchPlayers(t *testing.T) {
+	tests := []struct {
+		name string
+
+		givenSignals []*dbus.Signal
+
+		addMatchSignalErr               error
+		expectedAddMatchSignalCallCount int
+		expectedSignalCallCount         int
+
+		expectedErr    string
+		expectedEvents []PlayerEvent
+	}{
+		{
+			name:                            "happycase",
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         1,
+			givenSignals: []*dbus.Signal{
+				{
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.mpris.MediaPlayer2.vlc.instance12", "", ":1.12"},
+				}, {
+					Name: "unknown name",
+					Body: []interface{}{"org.mpris.MediaPlayer2.vlc", "", ":1.13"},
+				}, { // no media player
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.freedesktop.Notifications", "", ":1.14"},
+				}, { // missing body infos
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.mpris.MediaPlayer2.vlc", ""},
+				}, { // invalid body type
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.mpris.MediaPlayer2.vlc", 1, ":1.15"},
+				}, {
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.mpris.MediaPlayer2.spotify", ":1.16", ":1.17"},
+				}, {
+					Name: "org.freedesktop.DBus.NameOwnerChanged",
+					Body: []interface{}{"org.mpris.MediaPlayer2.vlc.instance12", ":1.12", ""},
+				},
+			},
+			expectedEvents: []PlayerEvent{
+				PlayerAdded{Handle: PlayerHandle{
+					BusName:    "org.mpris.MediaPlayer2.vlc.instance12",
+					UniqueName: ":1.12",
+					Name:       "vlc",
+					Instance:   ".instance12",
+				}},
+				PlayerOwnerChanged{Handle: PlayerHandle{
+					BusName:    "org.mpris.MediaPlayer2.spotify",
+					UniqueName: ":1.17",
+					Name:       "spotify",
+				}, OldUniqueName: ":1.16"},
+				PlayerRemoved{Handle: PlayerHandle{
+					BusName:    "org.mpris.MediaPlayer2.vlc.instance12",
+					UniqueName: ":1.12",
+					Name:       "vlc",
+					Instance:   ".instance12",
+				}},
+			},
+		}, {
+			name:                            "add match signal error",
+			addMatchSignalErr:               errors.New("unexpected error"),
+			expectedAddMatchSignalCallCount: 1,
+			expectedSignalCallCount:         0,
+			expectedErr:                     "failed to add signal match option: unexpected error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			mock := &dbusConnMock{
+				AddMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return tt.addMatchSignalErr
+				},
+				RemoveMatchSignalFunc: func(_ ...dbus.MatchOption) error {
+					return nil
+				},
+				SignalFunc: func(ch chan<- *dbus.Signal) {
+					go func() {
+						for _, sig := range tt.givenSignals {
+							ch <- sig
+						}
+					}()
+				},
+				RemoveSignalFunc: func(_ chan<- *dbus.Signal) {},
+			}
+
+			events, err := watchPlayers(testCtx, mock)
+			require.Equal(t, tt.expectedErr, msgOrEmpty(err))
+
+			var collectedEvents []PlayerEvent
+			if events != nil {
+				for len(collectedEvents) < len(tt.expectedEvents) {
+					e, ok := <-events
+					if !ok {
+						break
+					}
+					collectedEvents = append(collectedEvents, e)
+				}
+				cancel()
+				for range events { // wait until closed
+				}
+
+				assert.Equal(t, 1, len(mock.RemoveSignalCalls()))
+				assert.Equal(t, mock.AddMatchSignalCalls()[0].MatchOptions, mock.RemoveMatchSignalCalls()[0].MatchOptions)
+			}
+			assert.EqualValues(t, tt.expectedEvents, collectedEvents)
+			assert.Equal(t, tt.expectedAddMatchSignalCallCount, len(mock.AddMatchSignalCalls()))
+			assert.Equal(t, tt.expectedSignalCallCount, len(mock.SignalCalls()))
+		})
+	}
+}